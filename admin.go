@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"database-example/config"
+	"database-example/repo"
+	"database-example/snapshot"
+)
+
+// runAdmin izvršava admin podkomandu (export/import) i vraća exit kod.
+func runAdmin(logger *slog.Logger, cmd string, args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch cmd {
+	case "export":
+		err = runExport(ctx, logger, args)
+	case "import":
+		err = runImport(ctx, logger, args)
+	default:
+		err = fmt.Errorf("unknown command %q", cmd)
+	}
+	if err != nil {
+		logger.Error(cmd+" failed", "error", err)
+		return 1
+	}
+	return 0
+}
+
+func runExport(ctx context.Context, logger *slog.Logger, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("CONFIG_FILE"), "optional YAML/JSON config file")
+	out := fs.String("out", "-", "output file ('-' for stdout)")
+	format := fs.String("format", "", "jsonl or csv (default: by file extension, else jsonl)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := snapshot.ParseFormat(*format, *out)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return err
+	}
+	followerRepo, err := repo.NewFollowerRepository(cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer followerRepo.Close(context.Background())
+	if err := followerRepo.Connect(ctx); err != nil {
+		return err
+	}
+
+	stats, err := snapshot.Export(ctx, followerRepo, w, f, logger)
+	if err != nil {
+		return err
+	}
+	logger.Info("export done", "users", stats.Users, "follows", stats.Follows, "blocks", stats.Blocks)
+	return nil
+}
+
+func runImport(ctx context.Context, logger *slog.Logger, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("CONFIG_FILE"), "optional YAML/JSON config file")
+	in := fs.String("in", "-", "input file ('-' for stdin)")
+	format := fs.String("format", "", "jsonl or csv (default: by file extension, else jsonl)")
+	batch := fs.Int("batch", snapshot.DefaultBatchSize, "records per transaction")
+	dryRun := fs.Bool("dry-run", false, "validate input without writing to Neo4j")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := snapshot.ParseFormat(*format, *in)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *in != "-" {
+		file, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	// dry-run ne dira bazu, pa ni ne otvaramo konekciju
+	var store snapshot.Store
+	if !*dryRun {
+		cfg, err := config.Load(*configPath)
+		if err != nil {
+			return err
+		}
+		followerRepo, err := repo.NewFollowerRepository(cfg, logger)
+		if err != nil {
+			return err
+		}
+		defer followerRepo.Close(context.Background())
+		if err := followerRepo.Connect(ctx); err != nil {
+			return err
+		}
+		store = followerRepo
+	}
+
+	stats, err := snapshot.Import(ctx, store, r, f, snapshot.ImportOptions{
+		BatchSize: *batch,
+		DryRun:    *dryRun,
+	}, logger)
+	if err != nil {
+		return err
+	}
+	if *dryRun {
+		logger.Info("dry run ok, nothing written", "users", stats.Users, "follows", stats.Follows, "blocks", stats.Blocks)
+		return nil
+	}
+	logger.Info("import done", "users", stats.Users, "follows", stats.Follows, "skipped_follows", stats.SkippedFollows, "blocks", stats.Blocks)
+	return nil
+}
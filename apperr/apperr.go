@@ -0,0 +1,110 @@
+// Package apperr je zajednički model domenskih grešaka za repo i servis. Svaka
+// greška nosi Kind (određuje gRPC kod), mašinski čitljiv Reason i, za greške
+// validacije, listu polja. Handler-i ih ne mapiraju ručno: *Error implementira
+// GRPCStatus, pa ga grpc (i gateway preko status.Convert) prepoznaje i kad je wrap-ovan.
+package apperr
+
+import (
+	"errors"
+	"maps"
+	"slices"
+)
+
+// Domain ide u google.rpc.ErrorInfo.domain.
+const Domain = "follower-service"
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalidArgument
+	KindNotFound
+	KindFailedPrecondition
+	KindResourceExhausted
+	KindUnavailable
+)
+
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+type Error struct {
+	Kind Kind
+	// Reason je stabilan identifikator (UPPER_SNAKE_CASE) na koji se klijenti oslanjaju
+	Reason   string
+	Message  string
+	Fields   []FieldViolation
+	Metadata map[string]string
+	// Err je uzrok (opciono), dostupan kroz errors.Unwrap
+	Err error
+}
+
+func New(kind Kind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+// Invalid pravi InvalidArgument grešku sa povredama polja (google.rpc.BadRequest).
+func Invalid(reason, message string, fields ...FieldViolation) *Error {
+	return &Error{Kind: KindInvalidArgument, Reason: reason, Message: message, Fields: fields}
+}
+
+func Field(field, description string) FieldViolation {
+	return FieldViolation{Field: field, Description: description}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is poredi po Reason-u, pa errors.Is(err, ErrX) radi i za kopije napravljene
+// sa WithField/WithMetadata/Wrap.
+func (e *Error) Is(target error) bool {
+	var t *Error
+	return errors.As(target, &t) && t.Reason == e.Reason
+}
+
+func (e *Error) clone() *Error {
+	c := *e
+	c.Fields = slices.Clone(e.Fields)
+	c.Metadata = maps.Clone(e.Metadata)
+	return &c
+}
+
+// WithField vraća kopiju sa dodatom povredom polja (sentinel ostaje nepromenjen).
+func (e *Error) WithField(field, description string) *Error {
+	c := e.clone()
+	c.Fields = append(c.Fields, Field(field, description))
+	return c
+}
+
+// WithMetadata vraća kopiju sa dodatim ključem u ErrorInfo.metadata.
+func (e *Error) WithMetadata(key, value string) *Error {
+	c := e.clone()
+	if c.Metadata == nil {
+		c.Metadata = make(map[string]string)
+	}
+	c.Metadata[key] = value
+	return c
+}
+
+// Wrap vraća kopiju sa uzrokom.
+func (e *Error) Wrap(cause error) *Error {
+	c := e.clone()
+	c.Err = cause
+	return c
+}
+
+// As vraća *Error iz lanca grešaka, ako postoji.
+func As(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
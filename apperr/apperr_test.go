@@ -0,0 +1,177 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestKindCode(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		want codes.Code
+	}{
+		{KindInternal, codes.Internal},
+		{KindInvalidArgument, codes.InvalidArgument},
+		{KindNotFound, codes.NotFound},
+		{KindFailedPrecondition, codes.FailedPrecondition},
+		{KindResourceExhausted, codes.ResourceExhausted},
+		{KindUnavailable, codes.Unavailable},
+		{Kind(99), codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.Code(); got != tt.want {
+			t.Errorf("Kind(%d).Code() = %v, want %v", tt.kind, got, tt.want)
+		}
+	}
+}
+
+// details vraća ErrorInfo i BadRequest (ako postoji) iz statusa.
+func details(t *testing.T, st *status.Status) (*errdetails.ErrorInfo, *errdetails.BadRequest) {
+	t.Helper()
+	var info *errdetails.ErrorInfo
+	var br *errdetails.BadRequest
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			br = d
+		}
+	}
+	if info == nil {
+		t.Fatalf("status %v has no ErrorInfo", st)
+	}
+	return info, br
+}
+
+func TestGRPCStatusDetails(t *testing.T) {
+	base := New(KindNotFound, "USER_NOT_FOUND", "user not found")
+	err := base.WithMetadata("user_id", "alice").
+		WithField("followee_id", "unknown user").
+		Wrap(errors.New("driver: no rows"))
+
+	st := err.GRPCStatus()
+	if st.Code() != codes.NotFound || st.Message() != "user not found" {
+		t.Fatalf("status = %v %q, want NotFound %q", st.Code(), st.Message(), "user not found")
+	}
+	info, br := details(t, st)
+	if info.GetReason() != "USER_NOT_FOUND" || info.GetDomain() != Domain {
+		t.Errorf("ErrorInfo = %v/%v, want USER_NOT_FOUND/%v", info.GetReason(), info.GetDomain(), Domain)
+	}
+	if want := map[string]string{"user_id": "alice"}; !reflect.DeepEqual(info.GetMetadata(), want) {
+		t.Errorf("metadata = %v, want %v", info.GetMetadata(), want)
+	}
+	if br == nil || len(br.GetFieldViolations()) != 1 ||
+		br.GetFieldViolations()[0].GetField() != "followee_id" ||
+		br.GetFieldViolations()[0].GetDescription() != "unknown user" {
+		t.Errorf("BadRequest = %v, want one followee_id violation", br)
+	}
+
+	// sentinel ostaje netaknut
+	if base.Metadata != nil || base.Fields != nil || base.Err != nil {
+		t.Errorf("sentinel modified: %+v", base)
+	}
+}
+
+func TestGRPCStatusWithoutFields(t *testing.T) {
+	_, br := details(t, New(KindUnavailable, "DB_UNAVAILABLE", "database unavailable").GRPCStatus())
+	if br != nil {
+		t.Errorf("BadRequest = %v, want none", br)
+	}
+}
+
+func TestInvalidFields(t *testing.T) {
+	err := Invalid("INVALID_REQUEST", "request validation failed",
+		Field("skip", "must not be negative"), Field("limit", "must be between 0 and 100"))
+	st := err.GRPCStatus()
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %v, want InvalidArgument", st.Code())
+	}
+	_, br := details(t, st)
+	var got []string
+	for _, v := range br.GetFieldViolations() {
+		got = append(got, v.GetField()+": "+v.GetDescription())
+	}
+	want := []string{"skip: must not be negative", "limit: must be between 0 and 100"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations = %q, want %q", got, want)
+	}
+}
+
+func TestIsByReason(t *testing.T) {
+	sentinel := New(KindFailedPrecondition, "BLOCKED", "blocked")
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"same", sentinel, true},
+		{"copy with metadata", sentinel.WithMetadata("k", "v"), true},
+		{"wrapped by fmt", fmt.Errorf("follow: %w", sentinel.Wrap(errors.New("cause"))), true},
+		{"same reason other instance", New(KindInternal, "BLOCKED", "other"), true},
+		{"other reason", New(KindFailedPrecondition, "FOLLOW_LIMIT", "blocked"), false},
+		{"plain error", errors.New("blocked"), false},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(tt.err, sentinel); got != tt.want {
+			t.Errorf("%s: errors.Is = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUnwrapAndError(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := New(KindUnavailable, "DB_UNAVAILABLE", "database unavailable").Wrap(cause)
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is(err, cause) = false")
+	}
+	if got, want := err.Error(), "database unavailable: connection reset"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if e, ok := As(fmt.Errorf("x: %w", err)); !ok || e.Reason != "DB_UNAVAILABLE" {
+		t.Errorf("As = %v, %v", e, ok)
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	existing := status.Error(codes.PermissionDenied, "nope")
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+		reason  string
+	}{
+		{"apperr", New(KindNotFound, "USER_NOT_FOUND", "user not found"), codes.NotFound, "user not found", "USER_NOT_FOUND"},
+		{"wrapped apperr", fmt.Errorf("repo: %w", New(KindResourceExhausted, "FOLLOW_LIMIT", "limit")), codes.ResourceExhausted, "limit", "FOLLOW_LIMIT"},
+		{"status passes through", existing, codes.PermissionDenied, "nope", ""},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded, "follow deadline exceeded", ""},
+		{"wrapped deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded, "follow deadline exceeded", ""},
+		{"canceled", context.Canceled, codes.Canceled, "follow canceled", ""},
+		{"unknown", errors.New("neo4j: secret detail"), codes.Internal, "follow failed", "INTERNAL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(ToStatus(tt.err, "follow"))
+			if st.Code() != tt.code || st.Message() != tt.message {
+				t.Fatalf("status = %v %q, want %v %q", st.Code(), st.Message(), tt.code, tt.message)
+			}
+			if tt.reason == "" {
+				return
+			}
+			if info, _ := details(t, st); info.GetReason() != tt.reason {
+				t.Errorf("reason = %q, want %q", info.GetReason(), tt.reason)
+			}
+		})
+	}
+	if ToStatus(nil, "follow") != nil {
+		t.Error("ToStatus(nil) != nil")
+	}
+}
@@ -0,0 +1,78 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+func (k Kind) Code() codes.Code {
+	switch k {
+	case KindInvalidArgument:
+		return codes.InvalidArgument
+	case KindNotFound:
+		return codes.NotFound
+	case KindFailedPrecondition:
+		return codes.FailedPrecondition
+	case KindResourceExhausted:
+		return codes.ResourceExhausted
+	case KindUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// GRPCStatus pravi status sa ErrorInfo (reason, metadata) i, za greške
+// validacije, BadRequest sa povredama polja. Uzrok (Err) ne ide klijentu.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Kind.Code(), e.Message)
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   e.Reason,
+		Domain:   Domain,
+		Metadata: e.Metadata,
+	}}
+	if len(e.Fields) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, f := range e.Fields {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: f.Description,
+			})
+		}
+		details = append(details, br)
+	}
+	// WithDetails ne uspeva samo za OK status, a Kind nikad ne mapira na OK
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st
+}
+
+// ToStatus je jedino mesto gde se greške servisa prevode u gRPC status.
+// Istekao ili otkazan ctx postaje DeadlineExceeded/Canceled; ostale greške van
+// modela (drajver, mreža...) postaju Internal sa porukom "<op> failed",
+// bez uzroka; uzrok je već zalogovan u repo sloju.
+func ToStatus(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := As(err); ok {
+		return e.GRPCStatus().Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, op+" deadline exceeded")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, op+" canceled")
+	}
+	return New(KindInternal, "INTERNAL", op+" failed").GRPCStatus().Err()
+}
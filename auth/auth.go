@@ -0,0 +1,69 @@
+// Package auth određuje ko poziva servis: korisnik (JWT), interni servis
+// (servisni JWT sa ulogom iz ServiceAuthConfig.Role ili API ključ) ili anoniman.
+// Servisima scope-ovi ograničavaju koje RPC-ove smeju da zovu.
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+type Kind int
+
+const (
+	KindAnonymous Kind = iota
+	KindUser
+	KindService
+)
+
+// Način na koji se servis predstavio (za audit log).
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "api_key"
+)
+
+type Principal struct {
+	Kind Kind
+	// ID: korisnik iz JWT-a, odnosno ime servisa
+	ID     string
+	Method string
+	Scopes []string
+	// Admin: korisnik sa admin rolom (JWTConfig.AdminRole) sme da radi u ime drugih
+	Admin bool
+}
+
+func (p Principal) IsService() bool {
+	return p.Kind == KindService
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// Key je stabilan ključ pozivaoca za rate limit i idempotency ("user:<id>",
+// "service:<ime>"); prazan za anonimne.
+func (p Principal) Key() string {
+	if p.ID == "" {
+		return ""
+	}
+	switch p.Kind {
+	case KindUser:
+		return "user:" + p.ID
+	case KindService:
+		return "service:" + p.ID
+	default:
+		return ""
+	}
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext vraća pozivaoca koga je postavio interceptors.AuthUnary.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"database-example/config"
+	"database-example/util"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// APIKeyHeader je metadata ključ kojim se servis predstavlja API ključem.
+const APIKeyHeader = "x-api-key"
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrInvalidToken  = errors.New("invalid token")
+)
+
+type apiKey struct {
+	name   string
+	hash   []byte
+	scopes []string
+}
+
+// Authenticator razlikuje korisnike i servise. Poslat a nevalidan (istekao,
+// pogrešno potpisan) Bearer token ili nepoznat API ključ su greška, ne anonimni
+// poziv: inače bi pozivalac mogao da zaobiđe scope-ove tako što pošalje loš token.
+type Authenticator struct {
+	role      string
+	adminRole string
+	keys      []apiKey
+}
+
+func New(cfg config.ServiceAuthConfig, adminRole string) (*Authenticator, error) {
+	a := &Authenticator{role: cfg.Role, adminRole: adminRole}
+	for _, k := range cfg.APIKeys {
+		if err := checkScopes(k.Scopes); err != nil {
+			return nil, fmt.Errorf("service %q: %w", k.Name, err)
+		}
+		hash, err := hex.DecodeString(strings.ToLower(k.KeySHA256))
+		if err != nil {
+			return nil, fmt.Errorf("service %q: keySha256: %w", k.Name, err)
+		}
+		a.keys = append(a.keys, apiKey{name: k.Name, hash: hash, scopes: k.Scopes})
+	}
+	return a, nil
+}
+
+func checkScopes(scopes []string) error {
+	for _, s := range scopes {
+		if !slices.Contains(Scopes, s) {
+			return fmt.Errorf("unknown scope %q (known: %s)", s, strings.Join(Scopes, ", "))
+		}
+	}
+	return nil
+}
+
+// Authenticate određuje pozivaoca iz incoming metadata; bez API ključa i
+// Authorization header-a pozivalac je anoniman.
+func (a *Authenticator) Authenticate(ctx context.Context) (Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(APIKeyHeader); len(vals) > 0 {
+		return a.apiKeyPrincipal(vals[0])
+	}
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return Principal{Kind: KindAnonymous}, nil
+	}
+	token, err := util.ParseBearer(vals[0])
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	claims, err := util.ValidateToken(token)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return a.FromClaims(claims)
+}
+
+// FromClaims pravi pozivaoca od već proverenog JWT-a (HTTP gateway proverava
+// token sam, pa ga AuthUnary ne proverava ponovo).
+func (a *Authenticator) FromClaims(claims *util.Claims) (Principal, error) {
+	if claims.Role != a.role {
+		return Principal{Kind: KindUser, ID: claims.ID, Method: MethodJWT, Admin: strings.EqualFold(claims.Role, a.adminRole)}, nil
+	}
+
+	name := claims.Subject
+	if name == "" {
+		name = claims.ID
+	}
+	if name == "" {
+		return Principal{}, errors.New("service token has no sub or id claim")
+	}
+	// nepoznati scope-ovi se ignorišu, izdavalac može da ih deli sa drugim servisima
+	var scopes []string
+	for _, s := range strings.Fields(claims.Scope) {
+		if slices.Contains(Scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return Principal{Kind: KindService, ID: name, Method: MethodJWT, Scopes: scopes}, nil
+}
+
+func (a *Authenticator) apiKeyPrincipal(key string) (Principal, error) {
+	sum := sha256.Sum256([]byte(key))
+	// prolazi kroz sve ključeve da vreme odgovora ne otkriva koji je pogođen
+	var found *apiKey
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(sum[:], a.keys[i].hash) == 1 {
+			found = &a.keys[i]
+		}
+	}
+	if key == "" || found == nil {
+		return Principal{}, ErrInvalidAPIKey
+	}
+	return Principal{Kind: KindService, ID: found.name, Method: MethodAPIKey, Scopes: found.scopes}, nil
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"database-example/config"
+	followerpb "database-example/proto/follower"
+	"database-example/util"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+const testAPIKey = "s3cr3t-key"
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	sum := sha256.Sum256([]byte(testAPIKey))
+	a, err := New(config.ServiceAuthConfig{
+		Role: "service",
+		APIKeys: []config.ServiceAPIKey{{
+			Name:      "feed",
+			KeySHA256: hex.EncodeToString(sum[:]),
+			Scopes:    []string{ScopeFollowsRead},
+		}},
+	}, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func incoming(kv ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	p, err := a.Authenticate(incoming(APIKeyHeader, testAPIKey))
+	if err != nil {
+		t.Fatalf("valid key: %v", err)
+	}
+	want := Principal{Kind: KindService, ID: "feed", Method: MethodAPIKey, Scopes: []string{ScopeFollowsRead}}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("principal = %+v, want %+v", p, want)
+	}
+
+	for _, key := range []string{"wrong-key", ""} {
+		if _, err := a.Authenticate(incoming(APIKeyHeader, key)); !errors.Is(err, ErrInvalidAPIKey) {
+			t.Errorf("key %q: err = %v, want ErrInvalidAPIKey", key, err)
+		}
+	}
+}
+
+func TestNewRejectsBadKeyConfig(t *testing.T) {
+	tests := []config.ServiceAPIKey{
+		{Name: "a", KeySHA256: "not-hex", Scopes: []string{ScopeFollowsRead}},
+		{Name: "b", KeySHA256: "00", Scopes: []string{"follows:delete"}},
+	}
+	for _, k := range tests {
+		if _, err := New(config.ServiceAuthConfig{Role: "service", APIKeys: []config.ServiceAPIKey{k}}, "admin"); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", k)
+		}
+	}
+}
+
+func TestAuthenticateAnonymous(t *testing.T) {
+	p, err := newTestAuthenticator(t).Authenticate(context.Background())
+	if err != nil || p.Kind != KindAnonymous || p.Key() != "" {
+		t.Fatalf("got %+v, %v; want anonymous", p, err)
+	}
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	// bez ConfigureJWT util proverava HS256 tokene sa dev tajnom
+	token, err := util.GenerateToken("42", "alice", "user", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := a.Authenticate(incoming("authorization", "Bearer "+token))
+	if err != nil {
+		t.Fatalf("valid token: %v", err)
+	}
+	if p.Kind != KindUser || p.ID != "42" || p.Method != MethodJWT || p.Admin || p.Key() != "user:42" {
+		t.Errorf("principal = %+v, want user 42", p)
+	}
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &util.Claims{
+		ID:               "42",
+		Role:             "user",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour))},
+	}).SignedString([]byte(config.DevJWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, header := range []string{"Bearer " + expired, "Bearer not.a.jwt", "Basic abc"} {
+		if _, err := a.Authenticate(incoming("authorization", header)); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%q: err = %v, want ErrInvalidToken", header, err)
+		}
+	}
+}
+
+func TestFromClaims(t *testing.T) {
+	a := newTestAuthenticator(t)
+	tests := []struct {
+		name    string
+		claims  util.Claims
+		want    Principal
+		wantErr bool
+	}{
+		{
+			name:   "user",
+			claims: util.Claims{ID: "7", Role: "user"},
+			want:   Principal{Kind: KindUser, ID: "7", Method: MethodJWT},
+		},
+		{
+			name:   "admin role is case insensitive",
+			claims: util.Claims{ID: "1", Role: "Admin"},
+			want:   Principal{Kind: KindUser, ID: "1", Method: MethodJWT, Admin: true},
+		},
+		{
+			name: "service uses sub and drops unknown scopes",
+			claims: func() util.Claims {
+				c := util.Claims{ID: "ignored", Role: "service", Scope: "follows:read other:scope blocks:write"}
+				c.Subject = "feed"
+				return c
+			}(),
+			want: Principal{Kind: KindService, ID: "feed", Method: MethodJWT, Scopes: []string{ScopeFollowsRead, ScopeBlocksWrite}},
+		},
+		{
+			name:   "service falls back to id",
+			claims: util.Claims{ID: "search", Role: "service"},
+			want:   Principal{Kind: KindService, ID: "search", Method: MethodJWT},
+		},
+		{
+			name:    "service without name",
+			claims:  util.Claims{Role: "service"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := a.FromClaims(&tt.claims)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(p, tt.want) {
+				t.Errorf("principal = %+v, want %+v", p, tt.want)
+			}
+		})
+	}
+}
+
+func TestOwner(t *testing.T) {
+	tests := []struct {
+		req    any
+		want   string
+		wantOK bool
+	}{
+		{&followerpb.FollowRequest{FollowerId: "a", FolloweeId: "b"}, "a", true},
+		{&followerpb.UnfollowRequest{FollowerId: "a", FolloweeId: "b"}, "a", true},
+		{&followerpb.BlockRequest{BlockerId: "c", BlockedId: "d"}, "c", true},
+		{&followerpb.UnblockRequest{BlockerId: "c", BlockedId: "d"}, "c", true},
+		{&followerpb.GetFollowersRequest{UserId: "a"}, "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := Owner(tt.req); got != tt.want || ok != tt.wantOK {
+			t.Errorf("Owner(%T) = %q, %v; want %q, %v", tt.req, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
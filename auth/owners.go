@@ -0,0 +1,21 @@
+package auth
+
+import followerpb "database-example/proto/follower"
+
+// Owner vraća korisnika u čije ime write zahtev menja graf (follower_id, blocker_id).
+// Običan korisnik sme da radi samo u svoje ime; admin i servisi (po scope-u)
+// rade u ime bilo koga.
+func Owner(req any) (string, bool) {
+	switch r := req.(type) {
+	case *followerpb.FollowRequest:
+		return r.GetFollowerId(), true
+	case *followerpb.UnfollowRequest:
+		return r.GetFollowerId(), true
+	case *followerpb.BlockRequest:
+		return r.GetBlockerId(), true
+	case *followerpb.UnblockRequest:
+		return r.GetBlockerId(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,32 @@
+package auth
+
+import followerpb "database-example/proto/follower"
+
+// Scope-ovi servisnih identiteta.
+const (
+	ScopeFollowsRead         = "follows:read"
+	ScopeFollowsWrite        = "follows:write"
+	ScopeBlocksWrite         = "blocks:write"
+	ScopeRecommendationsRead = "recommendations:read"
+)
+
+var Scopes = []string{ScopeFollowsRead, ScopeFollowsWrite, ScopeBlocksWrite, ScopeRecommendationsRead}
+
+// methodScopes: scope koji servis mora imati za RPC; ove metode zahtevaju i
+// autentifikovanog pozivaoca. Metode kojih nema (Ping, health, reflection) su javne.
+var methodScopes = map[string]string{
+	followerpb.FollowerService_Follow_FullMethodName:             ScopeFollowsWrite,
+	followerpb.FollowerService_Unfollow_FullMethodName:           ScopeFollowsWrite,
+	followerpb.FollowerService_Block_FullMethodName:              ScopeBlocksWrite,
+	followerpb.FollowerService_Unblock_FullMethodName:            ScopeBlocksWrite,
+	followerpb.FollowerService_GetFollowees_FullMethodName:       ScopeFollowsRead,
+	followerpb.FollowerService_GetFollowers_FullMethodName:       ScopeFollowsRead,
+	followerpb.FollowerService_GetFollowCounts_FullMethodName:    ScopeFollowsRead,
+	followerpb.FollowerService_GetFollowPolicy_FullMethodName:    ScopeFollowsRead,
+	followerpb.FollowerService_GetRecommendations_FullMethodName: ScopeRecommendationsRead,
+}
+
+// RequiredScope vraća scope za puno ime metode; "" ako scope nije potreban.
+func RequiredScope(fullMethod string) string {
+	return methodScopes[fullMethod]
+}
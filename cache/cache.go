@@ -0,0 +1,23 @@
+// Package cache je read-through keš servisnog sloja. Unosi nose tagove
+// (npr. "followees:<userID>") da bi Follow/Unfollow mogli precizno da
+// invalidiraju samo ono što se promenilo.
+package cache
+
+import "context"
+
+// Cache implementacije moraju biti bezbedne za konkurentnu upotrebu. Deljeni
+// store (Redis...) bi vrednosti serijalizovao; in-memory ih čuva direktno, pa
+// pozivaoci ne smeju menjati vraćene vrednosti.
+type Cache interface {
+	Get(ctx context.Context, key string) (any, bool)
+	Set(ctx context.Context, key string, value any, tags ...string)
+	// Invalidate briše sve unose označene bilo kojim od tagova.
+	Invalidate(ctx context.Context, tags ...string)
+}
+
+// Noop ne kešira ništa (keš isključen u konfiguraciji).
+type Noop struct{}
+
+func (Noop) Get(context.Context, string) (any, bool)     { return nil, false }
+func (Noop) Set(context.Context, string, any, ...string) {}
+func (Noop) Invalidate(context.Context, ...string)       {}
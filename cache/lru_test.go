@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestLRU(size int, ttl time.Duration) (*LRU, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU(size, ttl)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func has(c *LRU, key string) bool {
+	_, ok := c.Get(context.Background(), key)
+	return ok
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestLRU(2, time.Minute)
+
+	c.Set(ctx, "a", 1)
+	c.Set(ctx, "b", 2)
+	// Get pomera "a" napred, pa ispada "b"
+	if v, ok := c.Get(ctx, "a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v", v, ok)
+	}
+	c.Set(ctx, "c", 3)
+
+	if !has(c, "a") || has(c, "b") || !has(c, "c") {
+		t.Errorf("after eviction: a=%v b=%v c=%v, want a and c", has(c, "a"), has(c, "b"), has(c, "c"))
+	}
+}
+
+func TestLRUOverwriteReplacesTags(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestLRU(10, time.Minute)
+
+	c.Set(ctx, "k", 1, "old")
+	c.Set(ctx, "k", 2, "new")
+	if c.order.Len() != 1 {
+		t.Fatalf("entries = %d, want 1", c.order.Len())
+	}
+	// stari tag više ne pokazuje na ključ
+	c.Invalidate(ctx, "old")
+	if v, ok := c.Get(ctx, "k"); !ok || v != 2 {
+		t.Fatalf("Get(k) = %v, %v; want 2", v, ok)
+	}
+	c.Invalidate(ctx, "new")
+	if has(c, "k") {
+		t.Error("k survived invalidation of its tag")
+	}
+}
+
+func TestLRUExpires(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestLRU(10, time.Minute)
+
+	c.Set(ctx, "k", 1, "t")
+	*now = now.Add(59 * time.Second)
+	if !has(c, "k") {
+		t.Fatal("entry expired before TTL")
+	}
+	*now = now.Add(time.Second)
+	if has(c, "k") {
+		t.Fatal("entry served after TTL")
+	}
+	if len(c.items) != 0 || len(c.tags) != 0 {
+		t.Errorf("expired entry left in index: items=%d tags=%d", len(c.items), len(c.tags))
+	}
+}
+
+func TestLRUInvalidateByTag(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestLRU(10, time.Minute)
+
+	c.Set(ctx, "followees:a:0:10", 1, "followees:a")
+	c.Set(ctx, "followees:a:10:10", 2, "followees:a")
+	c.Set(ctx, "counts:a", 3, "counts:a")
+	c.Set(ctx, "both", 4, "followees:a", "counts:b")
+	c.Set(ctx, "followees:b:0:10", 5, "followees:b")
+
+	c.Invalidate(ctx, "followees:a", "missing")
+
+	for key, want := range map[string]bool{
+		"followees:a:0:10":  false,
+		"followees:a:10:10": false,
+		"both":              false,
+		"counts:a":          true,
+		"followees:b:0:10":  true,
+	} {
+		if got := has(c, key); got != want {
+			t.Errorf("%s cached = %v, want %v", key, got, want)
+		}
+	}
+	// uklonjen unos se briše i iz indeksa ostalih tagova
+	if _, ok := c.tags["counts:b"]; ok {
+		t.Error("tag index still references an invalidated entry")
+	}
+}
+
+func TestLRUEvictionCleansTags(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestLRU(1, time.Minute)
+
+	c.Set(ctx, "a", 1, "t")
+	c.Set(ctx, "b", 2)
+	if _, ok := c.tags["t"]; ok {
+		t.Error("evicted entry left in tag index")
+	}
+}
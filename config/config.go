@@ -1,13 +1,881 @@
 package config
 
-import "os"
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"gopkg.in/yaml.v3"
+)
+
+// Config je jedini izvor podešavanja servisa. Redosled primene:
+// podrazumevane vrednosti -> opcioni YAML/JSON fajl -> env varijable.
 type Config struct {
-	Address string
+	// Environment: "development" ili "production" (APP_ENV); production ne dozvoljava dev podrazumevane vrednosti
+	Environment string `yaml:"environment"`
+	// Address na kom sluša gRPC server (FOLLOWER_SERVICE_ADDRESS)
+	Address string `yaml:"address"`
+	// HTTPAddress za REST/JSON gateway (FOLLOWER_HTTP_ADDRESS); prazno = isključen
+	HTTPAddress string `yaml:"httpAddress"`
+	// MetricsAddress za Prometheus /metrics (METRICS_ADDRESS); prazno = isključen
+	MetricsAddress string `yaml:"metricsAddress"`
+	LogLevel       string `yaml:"logLevel"`
+	// DrainTimeout je koliko GracefulStop čeka in-flight zahteve pre nasilnog Stop-a
+	DrainTimeout    time.Duration         `yaml:"drainTimeout"`
+	Neo4j           Neo4jConfig           `yaml:"neo4j"`
+	JWT             JWTConfig             `yaml:"jwt"`
+	GRPCWeb         GRPCWebConfig         `yaml:"grpcWeb"`
+	Tracing         TracingConfig         `yaml:"tracing"`
+	RateLimit       RateLimitConfig       `yaml:"rateLimit"`
+	FollowPolicy    FollowPolicyConfig    `yaml:"followPolicy"`
+	Idempotency     IdempotencyConfig     `yaml:"idempotency"`
+	Cache           CacheConfig           `yaml:"cache"`
+	Recommendations RecommendationsConfig `yaml:"recommendations"`
+	Validation      ValidationConfig      `yaml:"validation"`
+	Pagination      PaginationConfig      `yaml:"pagination"`
+	Timeouts        TimeoutConfig         `yaml:"timeouts"`
+	TLS             ServerTLSConfig       `yaml:"tls"`
+	ServiceAuth     ServiceAuthConfig     `yaml:"serviceAuth"`
+}
+
+// ServiceAuthConfig: identiteti internih servisa koji zovu FollowerService bez
+// korisničkog tokena. Servis se predstavlja API ključem (x-api-key) ili JWT-om
+// sa role claim-om Role; scope-ovi određuju koje RPC-ove sme da zove.
+type ServiceAuthConfig struct {
+	Role    string          `yaml:"role"`
+	APIKeys []ServiceAPIKey `yaml:"apiKeys"`
+}
+
+// ServiceAPIKey: u konfiguraciji je samo SHA-256 ključa (hex), ne i sam ključ.
+type ServiceAPIKey struct {
+	Name      string   `yaml:"name"`
+	KeySHA256 string   `yaml:"keySha256"`
+	Scopes    []string `yaml:"scopes"`
+}
+
+// ServerTLSConfig: TLS za gRPC listener; prazan CertFile = plaintext. ClientAuth
+// "request"/"require" proverava klijentske sertifikate (mTLS) protiv ClientCAFile.
+// Fajlovi se proveravaju na svakih ReloadInterval i menjaju bez restarta.
+type ServerTLSConfig struct {
+	CertFile       string        `yaml:"certFile"`
+	KeyFile        string        `yaml:"keyFile"`
+	ClientCAFile   string        `yaml:"clientCAFile"`
+	ClientAuth     string        `yaml:"clientAuth"`
+	ReloadInterval time.Duration `yaml:"reloadInterval"`
+}
+
+func (t ServerTLSConfig) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// TimeoutConfig: rok za unary RPC kad ga klijent ne pošalje. Methods (po imenu
+// metode, npr. "GetRecommendations") menja Default za pojedinačne operacije.
+type TimeoutConfig struct {
+	Default time.Duration            `yaml:"default"`
+	Methods map[string]time.Duration `yaml:"methods"`
+}
+
+// For vraća rok za metodu ("Follow", ne puno ime).
+func (t TimeoutConfig) For(method string) time.Duration {
+	if d, ok := t.Methods[method]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// PaginationConfig: veličina strane za liste (followees, followers, preporuke).
+type PaginationConfig struct {
+	// DefaultPageSize važi kad klijent ne pošalje limit
+	DefaultPageSize int `yaml:"defaultPageSize"`
+	MaxPageSize     int `yaml:"maxPageSize"`
+	// ClampLimit: veći limit se smanjuje na MaxPageSize; inače se zahtev odbija (InvalidArgument)
+	ClampLimit bool `yaml:"clampLimit"`
+}
+
+// ValidationConfig: IDFormat je oblik korisničkih ID-jeva ("any", "uuid", "numeric"),
+// isti kakav izdaje servis koji pravi korisnike.
+type ValidationConfig struct {
+	IDFormat string `yaml:"idFormat"`
+}
+
+// RecommendationsConfig: pozadinski worker unapred računa TopN preporuka za aktivne
+// korisnike; GetRecommendations ih služi dok nisu starije od MaxAge, inače računa uživo.
+type RecommendationsConfig struct {
+	Precompute bool `yaml:"precompute"`
+	TopN       int  `yaml:"topN"`
+	// RefreshInterval: koliko često worker traži zastarele skupove
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+	MaxAge          time.Duration `yaml:"maxAge"`
+	// ActiveWindow: korisnik je aktivan ako je pratio/otpratio nekog u ovom prozoru
+	ActiveWindow   time.Duration `yaml:"activeWindow"`
+	BatchSize      int           `yaml:"batchSize"`
+	ComputeTimeout time.Duration `yaml:"computeTimeout"`
+}
+
+// CacheConfig: read-through keš u servisu (followee liste, brojevi, preporuke).
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Size je najveći broj unosa (LRU)
+	Size int           `yaml:"size"`
+	TTL  time.Duration `yaml:"ttl"`
+}
+
+// IdempotencyConfig: koliko dugo se pamti rezultat write RPC-a za idempotency-key.
+type IdempotencyConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	TTL     time.Duration `yaml:"ttl"`
 }
 
-func GetConfig() Config {
+// FollowPolicyConfig su biznis limiti za praćenje; 0 isključuje pojedinačno pravilo.
+type FollowPolicyConfig struct {
+	MaxFollowees     int `yaml:"maxFollowees"`
+	DailyFollowQuota int `yaml:"dailyFollowQuota"`
+	// ChurnMaxChanges: najviše follow+unfollow promena nad istim korisnikom u ChurnWindow
+	ChurnWindow     time.Duration `yaml:"churnWindow"`
+	ChurnMaxChanges int           `yaml:"churnMaxChanges"`
+}
+
+// MaxChurnWindow je koliko repo čuva istoriju praćenja (FOLLOW_HISTORY).
+const MaxChurnWindow = 7 * 24 * time.Hour
+
+// RateLimitConfig: odvojeni budžeti po korisniku za pisanje (Follow/Unfollow/
+// Block/Unblock) i za čitanje.
+type RateLimitConfig struct {
+	Enabled bool        `yaml:"enabled"`
+	Write   LimitConfig `yaml:"write"`
+	Read    LimitConfig `yaml:"read"`
+}
+
+type LimitConfig struct {
+	RequestsPerMinute float64 `yaml:"requestsPerMinute"`
+	Burst             int     `yaml:"burst"`
+}
+
+// GRPCWebConfig: gRPC-Web se servira na HTTPAddress, pored REST gateway-a.
+type GRPCWebConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// AllowedOrigins za CORS; "*" dozvoljava sve, prazno = samo same-origin
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+}
+
+// TracingConfig: Exporter "otlp" šalje span-ove kolektoru (gRPC) na OTLPEndpoint,
+// "stdout" ih ispisuje (lokalni razvoj), "none" isključuje eksport.
+type TracingConfig struct {
+	Exporter     string `yaml:"exporter"`
+	OTLPEndpoint string `yaml:"otlpEndpoint"`
+	// Insecure = bez TLS-a ka kolektoru (sidecar / isti cluster)
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sampleRatio"`
+	ServiceName string  `yaml:"serviceName"`
+}
+
+type Neo4jConfig struct {
+	URI      string `yaml:"uri"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// Database prazno = podrazumevana baza servera
+	Database              string        `yaml:"database"`
+	MaxConnectionPoolSize int           `yaml:"maxConnectionPoolSize"`
+	ConnectTimeout        time.Duration `yaml:"connectTimeout"`
+	MigrationTimeout      time.Duration `yaml:"migrationTimeout"`
+	// ConnectionAcquisitionTimeout: koliko upit najviše čeka slobodnu konekciju iz pool-a
+	ConnectionAcquisitionTimeout time.Duration `yaml:"connectionAcquisitionTimeout"`
+	// MaxTransactionRetryTime: ukupno vreme ponavljanja transakcije posle prolaznih grešaka (npr. promena lidera u klasteru)
+	MaxTransactionRetryTime time.Duration `yaml:"maxTransactionRetryTime"`
+	MaxConnectionLifetime   time.Duration `yaml:"maxConnectionLifetime"`
+	// ConnectRetry važi za startup, dok baza (npr. u docker-compose) još nije gore
+	ConnectRetry RetryConfig    `yaml:"connectRetry"`
+	TLS          Neo4jTLSConfig `yaml:"tls"`
+}
+
+// Neo4jTLSConfig važi samo uz šeme sa enkripcijom (bolt+s, neo4j+s). Trust:
+// "system" = sistemski CA, "ca" = samo CAFile, "all" = bez provere sertifikata
+// (kao +ssc šema, samo za razvoj).
+type Neo4jTLSConfig struct {
+	Trust  string `yaml:"trust"`
+	CAFile string `yaml:"caFile"`
+	// ClientCertFile/ClientKeyFile: klijentski sertifikat ako baza traži mTLS
+	ClientCertFile string `yaml:"clientCertFile"`
+	ClientKeyFile  string `yaml:"clientKeyFile"`
+}
+
+// RetryConfig opisuje eksponencijalni backoff: InitialBackoff, pa svaki sledeći
+// put puta Multiplier, najviše MaxBackoff, ukupno MaxAttempts pokušaja.
+type RetryConfig struct {
+	MaxAttempts    int           `yaml:"maxAttempts"`
+	InitialBackoff time.Duration `yaml:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`
+	Multiplier     float64       `yaml:"multiplier"`
+}
+
+type JWTConfig struct {
+	// Secret je HMAC ključ (HS256)
+	Secret string `yaml:"secret"`
+	// TTL za tokene koje izdaje GenerateToken
+	TTL time.Duration `yaml:"ttl"`
+	// AdminRole je vrednost role claim-a koja daje pristup admin konzoli
+	AdminRole string `yaml:"adminRole"`
+	// Algorithms koje ValidateToken prihvata (HS256, RS256, ES256)
+	Algorithms []string `yaml:"algorithms"`
+	// PublicKeyFile: PEM sa javnim ključem (ili više njih) za RS256/ES256
+	PublicKeyFile string `yaml:"publicKeyFile"`
+	// JWKS: URL (http/https) ili putanja do JWKS dokumenta; ključ se bira po kid-u
+	JWKS            string        `yaml:"jwks"`
+	JWKSRefresh     time.Duration `yaml:"jwksRefresh"`
+	Issuer          string        `yaml:"issuer"`
+	Audience        string        `yaml:"audience"`
+	ClockSkewLeeway time.Duration `yaml:"clockSkewLeeway"`
+}
+
+// usesHMAC: HS256 je među dozvoljenim algoritmima, pa se Secret koristi.
+func (j JWTConfig) usesHMAC() bool {
+	return slices.Contains(j.Algorithms, "HS256")
+}
+
+// DevJWTSecret je dev fallback – nemoj ostaviti u produkciji.
+const DevJWTSecret = "tajna_lozinka"
+
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+var tracingExporters = []string{"none", "stdout", "otlp"}
+
+var environments = []string{"development", "production"}
+
+var jwtAlgorithms = []string{"HS256", "RS256", "ES256"}
+
+var clientAuthModes = []string{"none", "request", "require"}
+
+var neo4jTrust = []string{"system", "ca", "all"}
+
+var idFormats = []string{"any", "uuid", "numeric"}
+
+func Default() Config {
 	return Config{
-		Address: os.Getenv("FOLLOWER_SERVICE_ADDRESS"),
+		Environment:    "development",
+		Address:        ":50051",
+		HTTPAddress:    ":8080",
+		MetricsAddress: ":9090",
+		LogLevel:       "info",
+		DrainTimeout:   15 * time.Second,
+		Neo4j: Neo4jConfig{
+			MaxConnectionPoolSize: 100,
+			ConnectTimeout:        5 * time.Second,
+			MigrationTimeout:      30 * time.Second,
+			// kraće od podrazumevanog RPC roka, da zahtev ne čeka konekciju do isteka
+			ConnectionAcquisitionTimeout: 5 * time.Second,
+			MaxTransactionRetryTime:      15 * time.Second,
+			MaxConnectionLifetime:        time.Hour,
+			TLS:                          Neo4jTLSConfig{Trust: "system"},
+			ConnectRetry: RetryConfig{
+				MaxAttempts:    10,
+				InitialBackoff: 500 * time.Millisecond,
+				MaxBackoff:     15 * time.Second,
+				Multiplier:     2,
+			},
+		},
+		JWT: JWTConfig{
+			Secret:          DevJWTSecret,
+			TTL:             24 * time.Hour,
+			AdminRole:       "administrator",
+			Algorithms:      []string{"HS256"},
+			JWKSRefresh:     time.Hour,
+			ClockSkewLeeway: 30 * time.Second,
+		},
+		GRPCWeb: GRPCWebConfig{
+			Enabled: true,
+		},
+		ServiceAuth: ServiceAuthConfig{
+			Role: "service",
+		},
+		FollowPolicy: FollowPolicyConfig{
+			MaxFollowees:     5000,
+			DailyFollowQuota: 200,
+			ChurnWindow:      24 * time.Hour,
+			ChurnMaxChanges:  4,
+		},
+		Recommendations: RecommendationsConfig{
+			Precompute:      true,
+			TopN:            50,
+			RefreshInterval: 10 * time.Minute,
+			MaxAge:          time.Hour,
+			ActiveWindow:    72 * time.Hour,
+			BatchSize:       100,
+			ComputeTimeout:  30 * time.Second,
+		},
+		Cache: CacheConfig{
+			Enabled: true,
+			Size:    10000,
+			TTL:     30 * time.Second,
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled: true,
+			TTL:     24 * time.Hour,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Write:   LimitConfig{RequestsPerMinute: 30, Burst: 10},
+			Read:    LimitConfig{RequestsPerMinute: 600, Burst: 100},
+		},
+		Validation: ValidationConfig{
+			IDFormat: "any",
+		},
+		TLS: ServerTLSConfig{
+			ClientAuth:     "none",
+			ReloadInterval: 30 * time.Second,
+		},
+		Timeouts: TimeoutConfig{
+			Default: 10 * time.Second,
+			Methods: map[string]time.Duration{
+				// live preporuke su najskuplji upit
+				"GetRecommendations": 20 * time.Second,
+			},
+		},
+		Pagination: PaginationConfig{
+			DefaultPageSize: 20,
+			MaxPageSize:     100,
+			ClampLimit:      true,
+		},
+		Tracing: TracingConfig{
+			Exporter:     "none",
+			OTLPEndpoint: "localhost:4317",
+			Insecure:     true,
+			SampleRatio:  1,
+			ServiceName:  "follower-service",
+		},
+	}
+}
+
+// Load čita konfiguraciju. path može biti prazan (tada se čita samo env).
+// Sve greške validacije se vraćaju zajedno da bi se na startu videle odjednom.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+	envErr := loadEnv(&cfg)
+	if err := errors.Join(envErr, cfg.Validate()); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// loadFile podržava YAML i JSON (JSON je podskup YAML-a).
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func loadEnv(cfg *Config) error {
+	var errs []error
+
+	setString(&cfg.Environment, "APP_ENV")
+	setString(&cfg.Address, "FOLLOWER_SERVICE_ADDRESS")
+	setOptionalString(&cfg.HTTPAddress, "FOLLOWER_HTTP_ADDRESS")
+	setOptionalString(&cfg.MetricsAddress, "METRICS_ADDRESS")
+	setString(&cfg.LogLevel, "LOG_LEVEL")
+	errs = append(errs, setDuration(&cfg.DrainTimeout, "SHUTDOWN_DRAIN_TIMEOUT"))
+
+	// Podrži oba seta imena env varijabli (kao u Stakeholders i kao u ranijem compose-u)
+	setString(&cfg.Neo4j.URI, "NEO4J_DB", "NEO4J_URI")
+	setString(&cfg.Neo4j.Username, "NEO4J_USERNAME", "NEO4J_USER")
+	setString(&cfg.Neo4j.Password, "NEO4J_PASS", "NEO4J_PASSWORD")
+	setString(&cfg.Neo4j.Database, "NEO4J_DATABASE")
+	setString(&cfg.Neo4j.TLS.Trust, "NEO4J_TRUST")
+	setString(&cfg.Neo4j.TLS.CAFile, "NEO4J_CA_FILE")
+	setString(&cfg.Neo4j.TLS.ClientCertFile, "NEO4J_CLIENT_CERT_FILE")
+	setString(&cfg.Neo4j.TLS.ClientKeyFile, "NEO4J_CLIENT_KEY_FILE")
+	errs = append(errs,
+		setInt(&cfg.Neo4j.MaxConnectionPoolSize, "NEO4J_MAX_POOL_SIZE"),
+		setDuration(&cfg.Neo4j.ConnectTimeout, "NEO4J_CONNECT_TIMEOUT"),
+		setDuration(&cfg.Neo4j.MigrationTimeout, "NEO4J_MIGRATION_TIMEOUT"),
+		setDuration(&cfg.Neo4j.ConnectionAcquisitionTimeout, "NEO4J_CONNECTION_ACQUISITION_TIMEOUT"),
+		setDuration(&cfg.Neo4j.MaxTransactionRetryTime, "NEO4J_MAX_TX_RETRY_TIME"),
+		setDuration(&cfg.Neo4j.MaxConnectionLifetime, "NEO4J_MAX_CONNECTION_LIFETIME"),
+		setInt(&cfg.Neo4j.ConnectRetry.MaxAttempts, "NEO4J_CONNECT_ATTEMPTS"),
+		setDuration(&cfg.Neo4j.ConnectRetry.InitialBackoff, "NEO4J_CONNECT_BACKOFF"),
+		setDuration(&cfg.Neo4j.ConnectRetry.MaxBackoff, "NEO4J_CONNECT_MAX_BACKOFF"),
+	)
+
+	setString(&cfg.JWT.Secret, "JWT_SECRET")
+	errs = append(errs, setDuration(&cfg.JWT.TTL, "JWT_TTL"))
+	setString(&cfg.JWT.AdminRole, "JWT_ADMIN_ROLE")
+	setList(&cfg.JWT.Algorithms, "JWT_ALGORITHMS")
+	setString(&cfg.JWT.PublicKeyFile, "JWT_PUBLIC_KEY_FILE")
+	setString(&cfg.JWT.JWKS, "JWT_JWKS_URL", "JWT_JWKS")
+	setString(&cfg.JWT.Issuer, "JWT_ISSUER")
+	setString(&cfg.JWT.Audience, "JWT_AUDIENCE")
+	errs = append(errs,
+		setDuration(&cfg.JWT.JWKSRefresh, "JWT_JWKS_REFRESH"),
+		setDuration(&cfg.JWT.ClockSkewLeeway, "JWT_CLOCK_SKEW_LEEWAY"),
+	)
+	setString(&cfg.ServiceAuth.Role, "SERVICE_ROLE")
+
+	errs = append(errs, setBool(&cfg.GRPCWeb.Enabled, "GRPC_WEB_ENABLED"))
+	setList(&cfg.GRPCWeb.AllowedOrigins, "GRPC_WEB_ALLOWED_ORIGINS")
+
+	errs = append(errs,
+		setInt(&cfg.FollowPolicy.MaxFollowees, "FOLLOW_MAX_FOLLOWEES"),
+		setInt(&cfg.FollowPolicy.DailyFollowQuota, "FOLLOW_DAILY_QUOTA"),
+		setDuration(&cfg.FollowPolicy.ChurnWindow, "FOLLOW_CHURN_WINDOW"),
+		setInt(&cfg.FollowPolicy.ChurnMaxChanges, "FOLLOW_CHURN_MAX_CHANGES"),
+	)
+
+	errs = append(errs,
+		setBool(&cfg.Recommendations.Precompute, "RECS_PRECOMPUTE_ENABLED"),
+		setInt(&cfg.Recommendations.TopN, "RECS_TOP_N"),
+		setDuration(&cfg.Recommendations.RefreshInterval, "RECS_REFRESH_INTERVAL"),
+		setDuration(&cfg.Recommendations.MaxAge, "RECS_MAX_AGE"),
+		setDuration(&cfg.Recommendations.ActiveWindow, "RECS_ACTIVE_WINDOW"),
+		setInt(&cfg.Recommendations.BatchSize, "RECS_BATCH_SIZE"),
+		setDuration(&cfg.Recommendations.ComputeTimeout, "RECS_COMPUTE_TIMEOUT"),
+	)
+
+	errs = append(errs,
+		setBool(&cfg.Cache.Enabled, "CACHE_ENABLED"),
+		setInt(&cfg.Cache.Size, "CACHE_SIZE"),
+		setDuration(&cfg.Cache.TTL, "CACHE_TTL"),
+	)
+
+	errs = append(errs,
+		setBool(&cfg.Idempotency.Enabled, "IDEMPOTENCY_ENABLED"),
+		setDuration(&cfg.Idempotency.TTL, "IDEMPOTENCY_TTL"),
+	)
+
+	errs = append(errs,
+		setBool(&cfg.RateLimit.Enabled, "RATE_LIMIT_ENABLED"),
+		setFloat(&cfg.RateLimit.Write.RequestsPerMinute, "RATE_LIMIT_WRITE_PER_MINUTE"),
+		setInt(&cfg.RateLimit.Write.Burst, "RATE_LIMIT_WRITE_BURST"),
+		setFloat(&cfg.RateLimit.Read.RequestsPerMinute, "RATE_LIMIT_READ_PER_MINUTE"),
+		setInt(&cfg.RateLimit.Read.Burst, "RATE_LIMIT_READ_BURST"),
+	)
+
+	setString(&cfg.TLS.CertFile, "GRPC_TLS_CERT_FILE")
+	setString(&cfg.TLS.KeyFile, "GRPC_TLS_KEY_FILE")
+	setString(&cfg.TLS.ClientCAFile, "GRPC_TLS_CLIENT_CA_FILE")
+	setString(&cfg.TLS.ClientAuth, "GRPC_TLS_CLIENT_AUTH")
+	errs = append(errs, setDuration(&cfg.TLS.ReloadInterval, "GRPC_TLS_RELOAD_INTERVAL"))
+
+	errs = append(errs,
+		setDuration(&cfg.Timeouts.Default, "RPC_TIMEOUT_DEFAULT"),
+		setDurationMap(&cfg.Timeouts.Methods, "RPC_TIMEOUTS"),
+	)
+
+	setString(&cfg.Validation.IDFormat, "VALIDATION_ID_FORMAT")
+	errs = append(errs,
+		setInt(&cfg.Pagination.DefaultPageSize, "PAGE_SIZE_DEFAULT"),
+		setInt(&cfg.Pagination.MaxPageSize, "PAGE_SIZE_MAX"),
+		setBool(&cfg.Pagination.ClampLimit, "PAGE_SIZE_CLAMP"),
+	)
+
+	// standardna OTEL_* imena, kao u ostalim servisima
+	setString(&cfg.Tracing.Exporter, "OTEL_TRACES_EXPORTER")
+	setString(&cfg.Tracing.OTLPEndpoint, "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT")
+	setString(&cfg.Tracing.ServiceName, "OTEL_SERVICE_NAME")
+	errs = append(errs,
+		setBool(&cfg.Tracing.Insecure, "OTEL_EXPORTER_OTLP_INSECURE"),
+		setFloat(&cfg.Tracing.SampleRatio, "OTEL_TRACES_SAMPLER_ARG"),
+	)
+
+	return errors.Join(errs...)
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if c.Address == "" {
+		errs = append(errs, errors.New("address must not be empty"))
+	}
+	if !slices.Contains(logLevels, c.LogLevel) {
+		errs = append(errs, fmt.Errorf("logLevel must be one of %s, got %q", strings.Join(logLevels, ", "), c.LogLevel))
+	}
+	if c.HTTPAddress != "" && c.HTTPAddress == c.Address {
+		errs = append(errs, errors.New("httpAddress must differ from address"))
+	}
+	if c.MetricsAddress != "" && (c.MetricsAddress == c.Address || c.MetricsAddress == c.HTTPAddress) {
+		errs = append(errs, errors.New("metricsAddress must differ from address and httpAddress"))
+	}
+	if c.DrainTimeout <= 0 {
+		errs = append(errs, errors.New("drainTimeout must be positive"))
+	}
+	if c.Neo4j.URI == "" {
+		errs = append(errs, errors.New("neo4j.uri must be set (NEO4J_DB or NEO4J_URI)"))
+	}
+	if c.Neo4j.MaxConnectionPoolSize <= 0 {
+		errs = append(errs, errors.New("neo4j.maxConnectionPoolSize must be positive"))
+	}
+	if c.Neo4j.ConnectTimeout <= 0 {
+		errs = append(errs, errors.New("neo4j.connectTimeout must be positive"))
+	}
+	if c.Neo4j.MigrationTimeout <= 0 {
+		errs = append(errs, errors.New("neo4j.migrationTimeout must be positive"))
+	}
+	if c.Neo4j.ConnectionAcquisitionTimeout <= 0 || c.Neo4j.MaxTransactionRetryTime <= 0 || c.Neo4j.MaxConnectionLifetime <= 0 {
+		errs = append(errs, errors.New("neo4j.connectionAcquisitionTimeout, maxTransactionRetryTime and maxConnectionLifetime must be positive"))
+	}
+	errs = append(errs, c.Neo4j.ConnectRetry.validate("neo4j.connectRetry"))
+	errs = append(errs, c.Neo4j.TLS.validate(c.Neo4j.URI))
+	if c.GRPCWeb.Enabled && c.HTTPAddress == "" {
+		errs = append(errs, errors.New("grpcWeb requires httpAddress to be set"))
+	}
+	if !slices.Contains(environments, c.Environment) {
+		errs = append(errs, fmt.Errorf("environment must be one of %s, got %q", strings.Join(environments, ", "), c.Environment))
+	}
+	errs = append(errs, c.JWT.validate(c.Environment == "production"))
+	if c.JWT.TTL <= 0 {
+		errs = append(errs, errors.New("jwt.ttl must be positive"))
+	}
+	if c.JWT.AdminRole == "" {
+		errs = append(errs, errors.New("jwt.adminRole must not be empty"))
+	}
+	errs = append(errs, c.ServiceAuth.validate(c.JWT.AdminRole))
+	errs = append(errs, c.FollowPolicy.validate())
+	if c.Recommendations.Precompute {
+		errs = append(errs, c.Recommendations.validate())
+	}
+	if c.Cache.Enabled && (c.Cache.Size <= 0 || c.Cache.TTL <= 0) {
+		errs = append(errs, errors.New("cache.size and cache.ttl must be positive"))
+	}
+	if c.Idempotency.Enabled && c.Idempotency.TTL <= 0 {
+		errs = append(errs, errors.New("idempotency.ttl must be positive"))
+	}
+	if c.RateLimit.Enabled {
+		errs = append(errs,
+			c.RateLimit.Write.validate("rateLimit.write"),
+			c.RateLimit.Read.validate("rateLimit.read"),
+		)
+	}
+	if !slices.Contains(idFormats, c.Validation.IDFormat) {
+		errs = append(errs, fmt.Errorf("validation.idFormat must be one of %s, got %q", strings.Join(idFormats, ", "), c.Validation.IDFormat))
+	}
+	errs = append(errs, c.TLS.validate())
+	if c.Timeouts.Default <= 0 {
+		errs = append(errs, errors.New("timeouts.default must be positive"))
+	}
+	for method, d := range c.Timeouts.Methods {
+		if d <= 0 {
+			errs = append(errs, fmt.Errorf("timeouts.methods.%s must be positive", method))
+		}
+	}
+	if c.Pagination.DefaultPageSize <= 0 || c.Pagination.MaxPageSize < c.Pagination.DefaultPageSize {
+		errs = append(errs, errors.New("pagination.defaultPageSize must be positive and at most pagination.maxPageSize"))
+	}
+	if !slices.Contains(tracingExporters, c.Tracing.Exporter) {
+		errs = append(errs, fmt.Errorf("tracing.exporter must be one of %s, got %q", strings.Join(tracingExporters, ", "), c.Tracing.Exporter))
+	}
+	if c.Tracing.Exporter == "otlp" && c.Tracing.OTLPEndpoint == "" {
+		errs = append(errs, errors.New("tracing.otlpEndpoint must be set for the otlp exporter"))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sampleRatio must be between 0 and 1"))
+	}
+	if c.Tracing.ServiceName == "" {
+		errs = append(errs, errors.New("tracing.serviceName must not be empty"))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
+
+func (j JWTConfig) validate(production bool) error {
+	var errs []error
+	if len(j.Algorithms) == 0 {
+		errs = append(errs, errors.New("jwt.algorithms must not be empty"))
+	}
+	asymmetric := false
+	for _, alg := range j.Algorithms {
+		if !slices.Contains(jwtAlgorithms, alg) {
+			errs = append(errs, fmt.Errorf("jwt.algorithms: unsupported %q (supported: %s)", alg, strings.Join(jwtAlgorithms, ", ")))
+		}
+		asymmetric = asymmetric || alg != "HS256"
+	}
+	if j.usesHMAC() && j.Secret == "" {
+		errs = append(errs, errors.New("jwt.secret must not be empty when HS256 is allowed"))
+	}
+	// dev tajna je javna (u kodu), pa bi svako mogao da potpiše token
+	if production && j.usesHMAC() && j.Secret == DevJWTSecret {
+		errs = append(errs, errors.New("jwt.secret: the development fallback secret is not allowed in production; set JWT_SECRET or use RS256/ES256 keys"))
+	}
+	if asymmetric && j.PublicKeyFile == "" && j.JWKS == "" {
+		errs = append(errs, errors.New("jwt.publicKeyFile or jwt.jwks is required for RS256/ES256"))
+	}
+	if j.JWKS != "" && j.JWKSRefresh <= 0 {
+		errs = append(errs, errors.New("jwt.jwksRefresh must be positive"))
+	}
+	if j.ClockSkewLeeway < 0 || j.ClockSkewLeeway > 5*time.Minute {
+		errs = append(errs, errors.New("jwt.clockSkewLeeway must be between 0 and 5m"))
+	}
+	return errors.Join(errs...)
+}
+
+func (s ServiceAuthConfig) validate(adminRole string) error {
+	var errs []error
+	if s.Role == "" || s.Role == adminRole {
+		errs = append(errs, errors.New("serviceAuth.role must be set and differ from jwt.adminRole"))
+	}
+	names := map[string]bool{}
+	hashes := map[string]bool{}
+	for i, k := range s.APIKeys {
+		if k.Name == "" {
+			errs = append(errs, fmt.Errorf("serviceAuth.apiKeys[%d].name must not be empty", i))
+		} else if names[k.Name] {
+			errs = append(errs, fmt.Errorf("serviceAuth.apiKeys: duplicate name %q", k.Name))
+		}
+		names[k.Name] = true
+		hash := strings.ToLower(k.KeySHA256)
+		if b, err := hex.DecodeString(hash); err != nil || len(b) != sha256.Size {
+			errs = append(errs, fmt.Errorf("serviceAuth.apiKeys[%d].keySha256 must be a hex SHA-256 digest", i))
+		} else if hashes[hash] {
+			errs = append(errs, fmt.Errorf("serviceAuth.apiKeys[%d]: key already used by another service", i))
+		}
+		hashes[hash] = true
+		if len(k.Scopes) == 0 {
+			errs = append(errs, fmt.Errorf("serviceAuth.apiKeys[%d].scopes must not be empty", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t ServerTLSConfig) validate() error {
+	var errs []error
+	if !slices.Contains(clientAuthModes, t.ClientAuth) {
+		errs = append(errs, fmt.Errorf("tls.clientAuth must be one of %s, got %q", strings.Join(clientAuthModes, ", "), t.ClientAuth))
+	}
+	if !t.Enabled() {
+		if t.KeyFile != "" || t.ClientCAFile != "" || t.ClientAuth != "none" {
+			errs = append(errs, errors.New("tls.keyFile, clientCAFile and clientAuth require tls.certFile"))
+		}
+		return errors.Join(errs...)
+	}
+	if t.KeyFile == "" {
+		errs = append(errs, errors.New("tls.keyFile must be set together with tls.certFile"))
+	}
+	if t.ClientAuth != "none" && t.ClientCAFile == "" {
+		errs = append(errs, fmt.Errorf("tls.clientAuth %q requires tls.clientCAFile", t.ClientAuth))
+	}
+	if t.ReloadInterval <= 0 {
+		errs = append(errs, errors.New("tls.reloadInterval must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+func (t Neo4jTLSConfig) validate(uri string) error {
+	var errs []error
+	if !slices.Contains(neo4jTrust, t.Trust) {
+		errs = append(errs, fmt.Errorf("neo4j.tls.trust must be one of %s, got %q", strings.Join(neo4jTrust, ", "), t.Trust))
+	}
+	if (t.Trust == "ca") != (t.CAFile != "") {
+		errs = append(errs, errors.New(`neo4j.tls.caFile is required for, and only used with, trust "ca"`))
+	}
+	if (t.ClientCertFile == "") != (t.ClientKeyFile == "") {
+		errs = append(errs, errors.New("neo4j.tls.clientCertFile and clientKeyFile must be set together"))
+	}
+	scheme, _, _ := strings.Cut(uri, "://")
+	custom := t.Trust != "system" || t.ClientCertFile != ""
+	switch {
+	case custom && !strings.HasSuffix(scheme, "+s") && !strings.HasSuffix(scheme, "+ssc"):
+		errs = append(errs, fmt.Errorf("neo4j.tls settings require an encrypted URI scheme (bolt+s, neo4j+s), got %q", scheme))
+	case strings.HasSuffix(scheme, "+ssc") && t.Trust == "ca":
+		errs = append(errs, errors.New(`neo4j.tls.trust "ca" conflicts with a +ssc URI scheme, which skips verification`))
+	}
+	return errors.Join(errs...)
+}
+
+func (r RetryConfig) validate(prefix string) error {
+	var errs []error
+	if r.MaxAttempts <= 0 {
+		errs = append(errs, fmt.Errorf("%s.maxAttempts must be positive", prefix))
+	}
+	if r.InitialBackoff <= 0 {
+		errs = append(errs, fmt.Errorf("%s.initialBackoff must be positive", prefix))
+	}
+	if r.MaxBackoff < r.InitialBackoff {
+		errs = append(errs, fmt.Errorf("%s.maxBackoff must be >= initialBackoff", prefix))
+	}
+	if r.Multiplier < 1 {
+		errs = append(errs, fmt.Errorf("%s.multiplier must be >= 1", prefix))
+	}
+	return errors.Join(errs...)
+}
+
+func (p FollowPolicyConfig) validate() error {
+	var errs []error
+	if p.MaxFollowees < 0 {
+		errs = append(errs, errors.New("followPolicy.maxFollowees must not be negative"))
+	}
+	if p.DailyFollowQuota < 0 {
+		errs = append(errs, errors.New("followPolicy.dailyFollowQuota must not be negative"))
+	}
+	if p.ChurnMaxChanges < 0 {
+		errs = append(errs, errors.New("followPolicy.churnMaxChanges must not be negative"))
+	}
+	if p.ChurnMaxChanges > 0 && (p.ChurnWindow <= 0 || p.ChurnWindow > MaxChurnWindow) {
+		errs = append(errs, fmt.Errorf("followPolicy.churnWindow must be between 0 and %s", MaxChurnWindow))
+	}
+	return errors.Join(errs...)
+}
+
+func (r RecommendationsConfig) validate() error {
+	var errs []error
+	if r.TopN <= 0 {
+		errs = append(errs, errors.New("recommendations.topN must be positive"))
+	}
+	if r.BatchSize <= 0 {
+		errs = append(errs, errors.New("recommendations.batchSize must be positive"))
+	}
+	if r.RefreshInterval <= 0 || r.MaxAge <= 0 || r.ComputeTimeout <= 0 {
+		errs = append(errs, errors.New("recommendations.refreshInterval, maxAge and computeTimeout must be positive"))
+	}
+	if r.MaxAge < r.RefreshInterval {
+		errs = append(errs, errors.New("recommendations.maxAge must be >= refreshInterval"))
+	}
+	// aktivnost se čita iz FOLLOW_HISTORY, koja se ne čuva duže od MaxChurnWindow
+	if r.ActiveWindow <= 0 || r.ActiveWindow > MaxChurnWindow {
+		errs = append(errs, fmt.Errorf("recommendations.activeWindow must be between 0 and %s", MaxChurnWindow))
+	}
+	return errors.Join(errs...)
+}
+
+func (l LimitConfig) validate(prefix string) error {
+	var errs []error
+	if l.RequestsPerMinute <= 0 {
+		errs = append(errs, fmt.Errorf("%s.requestsPerMinute must be positive", prefix))
+	}
+	if l.Burst < 1 {
+		errs = append(errs, fmt.Errorf("%s.burst must be at least 1", prefix))
+	}
+	return errors.Join(errs...)
+}
+
+// setString uzima prvu nepraznu env varijablu iz liste.
+func setString(dst *string, keys ...string) {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			*dst = v
+			return
+		}
+	}
+}
+
+// setOptionalString dozvoljava i praznu vrednost (npr. za isključivanje HTTP-a),
+// ako je varijabla postavljena.
+func setOptionalString(dst *string, key string) {
+	if v, ok := os.LookupEnv(key); ok {
+		*dst = v
+	}
+}
+
+func setInt(dst *int, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*dst = n
+	return nil
+}
+
+func setBool(dst *bool, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*dst = b
+	return nil
+}
+
+// setList čita listu razdvojenu zarezima.
+func setList(dst *[]string, key string) {
+	v := os.Getenv(key)
+	if v == "" {
+		return
+	}
+	out := make([]string, 0)
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	*dst = out
+}
+
+func setFloat(dst *float64, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*dst = f
+	return nil
+}
+
+func setDuration(dst *time.Duration, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*dst = d
+	return nil
+}
+
+// setDurationMap čita parove "Ključ=trajanje" razdvojene zarezima i dopunjuje
+// (ne zamenjuje) postojeću mapu.
+func setDurationMap(dst *map[string]time.Duration, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	out := maps.Clone(*dst)
+	if out == nil {
+		out = make(map[string]time.Duration)
+	}
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("%s: expected name=duration, got %q", key, item)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		out[strings.TrimSpace(name)] = d
 	}
+	*dst = out
+	return nil
 }
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// requireErrors proverava da greška sadrži svaku od poruka.
+func requireErrors(t *testing.T, err error, want ...string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("err = nil, want %q", want)
+	}
+	for _, w := range want {
+		if !strings.Contains(err.Error(), w) {
+			t.Errorf("error does not mention %q:\n%v", w, err)
+		}
+	}
+}
+
+func TestDefaultIsValidWithNeo4jURI(t *testing.T) {
+	cfg := Default()
+	cfg.Neo4j.URI = "bolt://localhost:7687"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Default() is invalid: %v", err)
+	}
+}
+
+// podrazumevano < fajl < env
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `
+logLevel: debug
+neo4j:
+  uri: bolt://from-file:7687
+cache:
+  size: 500
+timeouts:
+  methods:
+    GetFollowers: 3s
+`)
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("RPC_TIMEOUTS", "Follow=2s")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.LogLevel != "warn" {
+		t.Errorf("logLevel = %q, want env value warn", cfg.LogLevel)
+	}
+	if cfg.Neo4j.URI != "bolt://from-file:7687" || cfg.Cache.Size != 500 {
+		t.Errorf("file values not applied: uri=%q cache.size=%d", cfg.Neo4j.URI, cfg.Cache.Size)
+	}
+	if cfg.Cache.TTL != 30*time.Second || cfg.Address != ":50051" {
+		t.Errorf("defaults lost: cache.ttl=%v address=%q", cfg.Cache.TTL, cfg.Address)
+	}
+	// env dopunjuje mapu iz fajla
+	if cfg.Timeouts.For("GetFollowers") != 3*time.Second || cfg.Timeouts.For("Follow") != 2*time.Second {
+		t.Errorf("timeouts = %v", cfg.Timeouts.Methods)
+	}
+}
+
+func TestLoadJSONFile(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"neo4j": {"uri": "neo4j://json:7687"}, "rateLimit": {"enabled": false}}`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Neo4j.URI != "neo4j://json:7687" || cfg.RateLimit.Enabled {
+		t.Errorf("cfg = uri %q rateLimit %v", cfg.Neo4j.URI, cfg.RateLimit.Enabled)
+	}
+}
+
+func TestLoadEnvOnly(t *testing.T) {
+	t.Setenv("NEO4J_DB", "bolt://env:7687")
+	t.Setenv("FOLLOWER_HTTP_ADDRESS", "")
+	t.Setenv("GRPC_WEB_ENABLED", "false")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// prazna vrednost isključuje HTTP gateway
+	if cfg.Neo4j.URI != "bolt://env:7687" || cfg.HTTPAddress != "" {
+		t.Errorf("cfg = uri %q httpAddress %q", cfg.Neo4j.URI, cfg.HTTPAddress)
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `
+neo4j:
+  uri: bolt://localhost:7687
+cahce:
+  size: 10
+`)
+	_, err := Load(path)
+	requireErrors(t, err, "cahce", "not found")
+}
+
+func TestLoadJoinsErrors(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `
+logLevel: loud
+cache:
+  size: 0
+validation:
+  idFormat: email
+`)
+	t.Setenv("RATE_LIMIT_WRITE_BURST", "ten")
+
+	_, err := Load(path)
+	// env greška i sve greške validacije dolaze zajedno
+	requireErrors(t, err,
+		"RATE_LIMIT_WRITE_BURST",
+		"neo4j.uri must be set",
+		`logLevel must be one of debug, info, warn, error, got "loud"`,
+		"cache.size and cache.ttl must be positive",
+		`validation.idFormat must be one of any, uuid, numeric, got "email"`,
+	)
+}
+
+func TestProductionRejectsDevSecret(t *testing.T) {
+	base := func() Config {
+		cfg := Default()
+		cfg.Neo4j.URI = "bolt://localhost:7687"
+		cfg.Environment = "production"
+		return cfg
+	}
+
+	requireErrors(t, base().Validate(), "development fallback secret is not allowed in production")
+
+	withSecret := base()
+	withSecret.JWT.Secret = "a-real-secret"
+	if err := withSecret.Validate(); err != nil {
+		t.Errorf("production with own secret: %v", err)
+	}
+
+	// bez HS256 se tajna ne koristi, pa dev vrednost ne smeta
+	asymmetric := base()
+	asymmetric.JWT.Algorithms = []string{"RS256"}
+	asymmetric.JWT.PublicKeyFile = "/etc/jwt/public.pem"
+	if err := asymmetric.Validate(); err != nil {
+		t.Errorf("production with RS256 only: %v", err)
+	}
+
+	dev := base()
+	dev.Environment = "development"
+	if err := dev.Validate(); err != nil {
+		t.Errorf("development with dev secret: %v", err)
+	}
+}
+
+func TestProductionFromEnv(t *testing.T) {
+	t.Setenv("NEO4J_URI", "bolt://localhost:7687")
+	t.Setenv("APP_ENV", "production")
+
+	_, err := Load("")
+	requireErrors(t, err, "development fallback secret")
+
+	t.Setenv("JWT_SECRET", "from-env")
+	if _, err := Load(""); err != nil {
+		t.Errorf("with JWT_SECRET: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	followerpb "database-example/proto/follower"
+	"database-example/static"
+	"database-example/util"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// registerAdmin: statička konzola je javna (nema tajni u njoj), a /admin/api/*
+// zahteva JWT sa admin rolom. Akcije se izvršavaju u ime korisnika iz putanje.
+func (g *Gateway) registerAdmin() {
+	g.mux.Handle("GET /admin/", http.StripPrefix("/admin/", http.FileServerFS(static.Files)))
+	g.mux.Handle("GET /{$}", http.RedirectHandler("/admin/", http.StatusFound))
+
+	g.mux.HandleFunc("GET /admin/api/users/{userId}", g.admin(g.adminCounts))
+	g.mux.HandleFunc("GET /admin/api/users/{userId}/followers", g.admin(g.followers))
+	g.mux.HandleFunc("GET /admin/api/users/{userId}/followees", g.admin(g.followees))
+	g.mux.HandleFunc("GET /admin/api/users/{userId}/recommendations", g.admin(g.recommendations))
+	g.mux.HandleFunc("POST /admin/api/users/{userId}/follow/{targetId}", g.admin(g.adminFollow))
+	g.mux.HandleFunc("DELETE /admin/api/users/{userId}/follow/{targetId}", g.admin(g.adminUnfollow))
+	g.mux.HandleFunc("POST /admin/api/users/{userId}/block/{targetId}", g.admin(g.adminBlock))
+	g.mux.HandleFunc("DELETE /admin/api/users/{userId}/block/{targetId}", g.admin(g.adminUnblock))
+}
+
+func (g *Gateway) admin(next authedHandler) http.HandlerFunc {
+	return g.authed(func(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims) {
+		if !strings.EqualFold(claims.Role, g.adminRole) {
+			writeError(w, status.Error(codes.PermissionDenied, "admin role required"))
+			return
+		}
+		next(w, r, ctx, claims)
+	})
+}
+
+func (g *Gateway) adminCounts(w http.ResponseWriter, r *http.Request, ctx context.Context, _ *util.Claims) {
+	resp, err := call(g, ctx, followerpb.FollowerService_GetFollowCounts_FullMethodName, g.srv.GetFollowCounts, &followerpb.GetFollowCountsRequest{UserId: r.PathValue("userId")})
+	g.respond(w, resp, err)
+}
+
+func (g *Gateway) adminFollow(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims) {
+	userID, targetID := r.PathValue("userId"), r.PathValue("targetId")
+	_, err := call(g, ctx, followerpb.FollowerService_Follow_FullMethodName, g.srv.Follow, &followerpb.FollowRequest{FollowerId: userID, FolloweeId: targetID})
+	g.adminResult(w, claims, "follow", userID, targetID, err)
+}
+
+func (g *Gateway) adminUnfollow(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims) {
+	userID, targetID := r.PathValue("userId"), r.PathValue("targetId")
+	_, err := call(g, ctx, followerpb.FollowerService_Unfollow_FullMethodName, g.srv.Unfollow, &followerpb.UnfollowRequest{FollowerId: userID, FolloweeId: targetID})
+	g.adminResult(w, claims, "unfollow", userID, targetID, err)
+}
+
+func (g *Gateway) adminBlock(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims) {
+	userID, targetID := r.PathValue("userId"), r.PathValue("targetId")
+	_, err := call(g, ctx, followerpb.FollowerService_Block_FullMethodName, g.srv.Block, &followerpb.BlockRequest{BlockerId: userID, BlockedId: targetID})
+	g.adminResult(w, claims, "block", userID, targetID, err)
+}
+
+func (g *Gateway) adminUnblock(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims) {
+	userID, targetID := r.PathValue("userId"), r.PathValue("targetId")
+	_, err := call(g, ctx, followerpb.FollowerService_Unblock_FullMethodName, g.srv.Unblock, &followerpb.UnblockRequest{BlockerId: userID, BlockedId: targetID})
+	g.adminResult(w, claims, "unblock", userID, targetID, err)
+}
+
+// adminResult beleži svaku admin izmenu (ko je, u čije ime, šta uradio).
+func (g *Gateway) adminResult(w http.ResponseWriter, claims *util.Claims, action, userID, targetID string, err error) {
+	if err != nil {
+		g.logger.Warn("admin action failed",
+			"admin_id", claims.ID,
+			"admin_username", claims.Username,
+			"action", action,
+			"user_id", userID,
+			"target_id", targetID,
+			"error", err,
+		)
+		writeError(w, err)
+		return
+	}
+	g.logger.Info("admin action",
+		"admin_id", claims.ID,
+		"admin_username", claims.Username,
+		"action", action,
+		"user_id", userID,
+		"target_id", targetID,
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
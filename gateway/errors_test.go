@@ -0,0 +1,118 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"database-example/apperr"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.OK, http.StatusOK},
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.OutOfRange, http.StatusBadRequest},
+		{codes.FailedPrecondition, http.StatusPreconditionFailed},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.AlreadyExists, http.StatusConflict},
+		{codes.Aborted, http.StatusConflict},
+		{codes.ResourceExhausted, http.StatusTooManyRequests},
+		{codes.Canceled, 499},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.Unimplemented, http.StatusNotImplemented},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.Internal, http.StatusInternalServerError},
+		{codes.Unknown, http.StatusInternalServerError},
+		{codes.DataLoss, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := httpStatus(tt.code); got != tt.want {
+			t.Errorf("httpStatus(%v) = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) errorDetail {
+	t.Helper()
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	var body errorBody
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return body.Error
+}
+
+func TestWriteErrorDetails(t *testing.T) {
+	err := apperr.Invalid("INVALID_REQUEST", "request validation failed",
+		apperr.Field("limit", "must be between 0 and 100")).
+		WithMetadata("method", "GetFollowers")
+
+	rec := httptest.NewRecorder()
+	writeError(rec, err)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+	want := errorDetail{
+		Code:            "INVALID_ARGUMENT",
+		Message:         "request validation failed",
+		Status:          http.StatusBadRequest,
+		Reason:          "INVALID_REQUEST",
+		Metadata:        map[string]string{"method": "GetFollowers"},
+		FieldViolations: []fieldViolation{{Field: "limit", Description: "must be between 0 and 100"}},
+	}
+	if got := decodeError(t, rec); !reflect.DeepEqual(got, want) {
+		t.Errorf("body = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteErrorRetryAfter(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	writeError(rec, st.Err())
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	// zaokružuje se naviše na cele sekunde
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want 2", got)
+	}
+	if got := decodeError(t, rec); got.Code != "RESOURCE_EXHAUSTED" {
+		t.Errorf("code = %q, want RESOURCE_EXHAUSTED", got.Code)
+	}
+}
+
+func TestWriteErrorPlain(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeError(rec, errors.New("boom"))
+
+	got := decodeError(t, rec)
+	if rec.Code != http.StatusInternalServerError || got.Code != "UNKNOWN" || got.Message != "boom" {
+		t.Errorf("got %d %+v, want 500 UNKNOWN boom", rec.Code, got)
+	}
+	if got.Reason != "" || got.FieldViolations != nil {
+		t.Errorf("unexpected details: %+v", got)
+	}
+}
@@ -0,0 +1,242 @@
+// Package gateway izlaže FollowerService kao REST/JSON API za web frontend.
+// Zahtevi idu kroz isti FollowerHandler kao gRPC, pa su i greške (kodovi) iste.
+package gateway
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"database-example/auth"
+	"database-example/interceptors"
+	"database-example/logging"
+	followerpb "database-example/proto/follower"
+	"database-example/tracing"
+	"database-example/util"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type Gateway struct {
+	srv       followerpb.FollowerServiceServer
+	logger    *slog.Logger
+	mux       *http.ServeMux
+	adminRole string
+	// auth pravi pozivaoca iz tokena koji authed već proveri (AuthUnary ga ne proverava ponovo)
+	auth *auth.Authenticator
+	// interceptor je isti lanac kao na gRPC serveru (metrike, ...)
+	interceptor grpc.UnaryServerInterceptor
+}
+
+var jsonOpts = protojson.MarshalOptions{EmitUnpopulated: true}
+
+func New(srv followerpb.FollowerServiceServer, interceptor grpc.UnaryServerInterceptor, authenticator *auth.Authenticator, adminRole string, logger *slog.Logger) *Gateway {
+	g := &Gateway{
+		srv:         srv,
+		logger:      logger,
+		mux:         http.NewServeMux(),
+		adminRole:   adminRole,
+		auth:        authenticator,
+		interceptor: interceptor,
+	}
+
+	// follower je uvek korisnik iz JWT-a
+	g.mux.HandleFunc("POST /api/follow/{followeeId}", g.authed(g.follow))
+	g.mux.HandleFunc("DELETE /api/follow/{followeeId}", g.authed(g.unfollow))
+	g.mux.HandleFunc("GET /api/users/{userId}/followees", g.authed(g.followees))
+	g.mux.HandleFunc("GET /api/users/{userId}/followers", g.authed(g.followers))
+	g.mux.HandleFunc("GET /api/users/{userId}/recommendations", g.authed(g.recommendations))
+	g.mux.HandleFunc("GET /api/follow-policy", g.authed(g.followPolicy))
+
+	g.registerAdmin()
+
+	return g
+}
+
+func (g *Gateway) Handler() http.Handler {
+	return g.mux
+}
+
+type authedHandler func(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims)
+
+// authed proverava Bearer JWT i prosleđuje Authorization dalje kao gRPC metadata,
+// da bi handler video isti kontekst kao kod pravog gRPC poziva.
+func (g *Gateway) authed(next authedHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request ID ide i u odgovor i u metadata, da ga logging interceptor prepozna
+		reqID := r.Header.Get(logging.RequestIDKey)
+		if reqID == "" {
+			reqID = uuid.NewString()
+		}
+		w.Header().Set(logging.RequestIDKey, reqID)
+
+		header := r.Header.Get("Authorization")
+		token, err := util.ParseBearer(header)
+		if err != nil {
+			writeError(w, status.Error(codes.Unauthenticated, err.Error()))
+			return
+		}
+		claims, err := util.ValidateToken(token)
+		if err != nil {
+			writeError(w, status.Error(codes.Unauthenticated, "invalid token"))
+			return
+		}
+		principal, err := g.auth.FromClaims(claims)
+		if err != nil {
+			writeError(w, status.Error(codes.Unauthenticated, "invalid token"))
+			return
+		}
+
+		md := metadata.Pairs("authorization", header, logging.RequestIDKey, reqID)
+		if key := r.Header.Get(interceptors.IdempotencyKeyHeader); key != "" {
+			md.Set(interceptors.IdempotencyKeyHeader, key)
+		}
+		// bookmark iz prethodnog write odgovora, za read-your-writes
+		if bookmarks := r.Header.Values(interceptors.BookmarkHeader); len(bookmarks) > 0 {
+			md.Set(interceptors.BookmarkHeader, bookmarks...)
+		}
+		// traceparent/tracestate idu dalje kao metadata, isto kao kod gRPC klijenta
+		otel.GetTextMapPropagator().Inject(
+			otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header)),
+			tracing.MetadataCarrier(md),
+		)
+		stream := &headerStream{}
+		ctx := grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(r.Context(), md), stream)
+		ctx = auth.WithPrincipal(ctx, principal)
+		next(&headerWriter{ResponseWriter: w, stream: stream}, r, ctx, claims)
+	}
+}
+
+func (g *Gateway) follow(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims) {
+	_, err := call(g, ctx, followerpb.FollowerService_Follow_FullMethodName, g.srv.Follow, &followerpb.FollowRequest{
+		FollowerId: claims.ID,
+		FolloweeId: r.PathValue("followeeId"),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (g *Gateway) unfollow(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims) {
+	_, err := call(g, ctx, followerpb.FollowerService_Unfollow_FullMethodName, g.srv.Unfollow, &followerpb.UnfollowRequest{
+		FollowerId: claims.ID,
+		FolloweeId: r.PathValue("followeeId"),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (g *Gateway) followees(w http.ResponseWriter, r *http.Request, ctx context.Context, _ *util.Claims) {
+	skip, limit, err := pageParams(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp, err := call(g, ctx, followerpb.FollowerService_GetFollowees_FullMethodName, g.srv.GetFollowees, &followerpb.GetFolloweesRequest{
+		UserId: r.PathValue("userId"),
+		Skip:   skip,
+		Limit:  limit,
+	})
+	g.respond(w, resp, err)
+}
+
+func (g *Gateway) followers(w http.ResponseWriter, r *http.Request, ctx context.Context, _ *util.Claims) {
+	skip, limit, err := pageParams(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp, err := call(g, ctx, followerpb.FollowerService_GetFollowers_FullMethodName, g.srv.GetFollowers, &followerpb.GetFollowersRequest{
+		UserId: r.PathValue("userId"),
+		Skip:   skip,
+		Limit:  limit,
+	})
+	g.respond(w, resp, err)
+}
+
+func (g *Gateway) recommendations(w http.ResponseWriter, r *http.Request, ctx context.Context, _ *util.Claims) {
+	limit, err := int32Param(r, "limit")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp, err := call(g, ctx, followerpb.FollowerService_GetRecommendations_FullMethodName, g.srv.GetRecommendations, &followerpb.GetRecommendationsRequest{
+		UserId: r.PathValue("userId"),
+		Limit:  limit,
+	})
+	g.respond(w, resp, err)
+}
+
+// followPolicy vraća limite praćenja za korisnika iz JWT-a.
+func (g *Gateway) followPolicy(w http.ResponseWriter, r *http.Request, ctx context.Context, claims *util.Claims) {
+	resp, err := call(g, ctx, followerpb.FollowerService_GetFollowPolicy_FullMethodName, g.srv.GetFollowPolicy, &followerpb.GetFollowPolicyRequest{
+		UserId: claims.ID,
+	})
+	g.respond(w, resp, err)
+}
+
+// call prosleđuje zahtev handler metodi kroz isti interceptor lanac kao gRPC server.
+func call[Req, Resp any](g *Gateway, ctx context.Context, fullMethod string, fn func(context.Context, Req) (Resp, error), req Req) (Resp, error) {
+	if g.interceptor == nil {
+		return fn(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: g.srv, FullMethod: fullMethod}
+	out, err := g.interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return fn(ctx, req.(Req))
+	})
+	resp, _ := out.(Resp)
+	return resp, err
+}
+
+func (g *Gateway) respond(w http.ResponseWriter, resp proto.Message, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body, err := jsonOpts.Marshal(resp)
+	if err != nil {
+		g.logger.Error("gateway: marshal response", "error", err)
+		writeError(w, status.Error(codes.Internal, "failed to encode response"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+func pageParams(r *http.Request) (skip, limit int32, err error) {
+	if skip, err = int32Param(r, "skip"); err != nil {
+		return 0, 0, err
+	}
+	if limit, err = int32Param(r, "limit"); err != nil {
+		return 0, 0, err
+	}
+	return skip, limit, nil
+}
+
+// int32Param čita opcioni query parametar; prazan znači 0 (default u servisu).
+func int32Param(r *http.Request, name string) (int32, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be an integer", name)
+	}
+	return int32(n), nil
+}
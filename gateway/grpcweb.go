@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"database-example/auth"
+	"database-example/util"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithGRPCWeb šalje gRPC-Web zahteve (i njihove CORS preflight-e) direktno na
+// grpcServer, tako da browser može da koristi generisane follower.proto stubove
+// bez Envoy-a. Sve ostalo ide na next (REST gateway).
+//
+// gRPC-Web je za korisnike iz browsera: bez Bearer tokena zahtev se odbija, a
+// API ključevi servisa se ne prihvataju. Token proverava AuthUnary, koji i
+// ograničava korisnika na follower_id/blocker_id iz JWT-a.
+func WithGRPCWeb(grpcServer *grpc.Server, allowedOrigins []string, next http.Handler) http.Handler {
+	wrapped := grpcweb.WrapServer(grpcServer,
+		grpcweb.WithOriginFunc(originAllowed(allowedOrigins)),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wrapped.IsAcceptableGrpcCorsRequest(r) {
+			wrapped.ServeHTTP(w, r)
+			return
+		}
+		if wrapped.IsGrpcWebRequest(r) {
+			if _, err := util.ParseBearer(r.Header.Get("Authorization")); err != nil {
+				// grpc-status da bi gRPC-Web klijent video Unauthenticated, ne samo HTTP 401
+				w.Header().Set("Grpc-Status", strconv.Itoa(int(codes.Unauthenticated)))
+				w.Header().Set("Grpc-Message", "authentication required")
+				writeError(w, status.Error(codes.Unauthenticated, "authentication required"))
+				return
+			}
+			r.Header.Del(auth.APIKeyHeader)
+			wrapped.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// originAllowed: "*" dozvoljava sve, inače tačno poklapanje (bez obzira na velika slova).
+func originAllowed(allowed []string) func(origin string) bool {
+	return func(origin string) bool {
+		for _, a := range allowed {
+			if a == "*" || strings.EqualFold(a, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
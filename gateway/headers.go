@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"net/http"
+	"sync"
+
+	"database-example/interceptors"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// forwardedHeaders su gRPC response header-i koje gateway prepisuje u HTTP odgovor.
+var forwardedHeaders = []string{
+	interceptors.BookmarkHeader,
+	interceptors.IdempotentReplayHeader,
+}
+
+// headerStream je grpc.ServerTransportStream za pozive iz gateway-a: nema pravog
+// stream-a, pa samo pamti šta interceptori i handler-i pošalju sa grpc.SetHeader.
+type headerStream struct {
+	mu sync.Mutex
+	md metadata.MD
+}
+
+func (s *headerStream) Method() string { return "" }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.md = metadata.Join(s.md, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerStream) SetTrailer(metadata.MD) error { return nil }
+
+// headerWriter upisuje sakupljene header-e neposredno pre statusa odgovora.
+type headerWriter struct {
+	http.ResponseWriter
+	stream  *headerStream
+	flushed bool
+}
+
+func (w *headerWriter) WriteHeader(code int) {
+	w.flush()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *headerWriter) Write(b []byte) (int, error) {
+	w.flush()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *headerWriter) flush() {
+	if w.flushed {
+		return
+	}
+	w.flushed = true
+	w.stream.mu.Lock()
+	defer w.stream.mu.Unlock()
+	for _, key := range forwardedHeaders {
+		for _, v := range w.stream.md.Get(key) {
+			w.Header().Add(key, v)
+		}
+	}
+}
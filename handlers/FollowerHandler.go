@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 
+	"database-example/apperr"
 	followerpb "database-example/proto/follower"
 	"database-example/repo"
 	"database-example/service"
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type FollowerHandler struct {
@@ -23,39 +25,27 @@ func NewFollowerHandler(svc *service.FollowerService) *FollowerHandler {
 	return &FollowerHandler{Svc: svc}
 }
 
-// Opcioni health check da probaš konekciju (ako imaš service.Ping, pozovi njega)
+// Health check: vraća Unavailable dok baza nije spremna
 func (h *FollowerHandler) Ping(ctx context.Context, _ *followerpb.PingRequest) (*followerpb.PingResponse, error) {
+	if err := h.Svc.Ping(ctx); err != nil {
+		if errors.Is(err, repo.ErrNotReady) {
+			return nil, apperr.ToStatus(err, "ping")
+		}
+		return nil, status.Errorf(codes.Unavailable, "database unavailable: %v", err)
+	}
 	return &followerpb.PingResponse{Message: "pong"}, nil
 }
 
 func (h *FollowerHandler) Follow(ctx context.Context, req *followerpb.FollowRequest) (*emptypb.Empty, error) {
-	if err := h.Svc.Follow(req.FollowerId, req.FolloweeId); err != nil {
-		switch err {
-		case service.ErrInvalidIDs:
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		case repo.ErrUserNotFound:
-			return nil, status.Error(codes.NotFound, err.Error())
-		default:
-			return nil, status.Error(codes.Internal, "db error")
-		}
+	if err := h.Svc.Follow(ctx, req.GetFollowerId(), req.GetFolloweeId()); err != nil {
+		return nil, apperr.ToStatus(err, "follow")
 	}
 	return &emptypb.Empty{}, nil
 }
 
 func (h *FollowerHandler) Unfollow(ctx context.Context, req *followerpb.UnfollowRequest) (*emptypb.Empty, error) {
-	if req.GetFollowerId() == "" || req.GetFolloweeId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "missing follower_id or followee_id")
-	}
-	err := h.Svc.Unfollow(ctx, req.GetFollowerId(), req.GetFolloweeId())
-	if err != nil {
-		switch {
-		case errors.Is(err, repo.ErrNotFollowing):
-			return nil, status.Error(codes.NotFound, "not following")
-		case err.Error() == "cannot unfollow self":
-			return nil, status.Error(codes.InvalidArgument, "cannot unfollow self")
-		default:
-			return nil, status.Errorf(codes.Internal, "unfollow failed: %v", err)
-		}
+	if err := h.Svc.Unfollow(ctx, req.GetFollowerId(), req.GetFolloweeId()); err != nil {
+		return nil, apperr.ToStatus(err, "unfollow")
 	}
 	return &emptypb.Empty{}, nil
 }
@@ -65,37 +55,102 @@ func (h *FollowerHandler) GetFollowees(ctx context.Context, req *followerpb.GetF
 	skip := int(req.GetSkip())
 	limit := int(req.GetLimit())
 
-	ids, err := h.Svc.GetFollowees(ctx, userID, skip, limit)
+	page, err := h.Svc.GetFollowees(ctx, userID, skip, limit)
 	if err != nil {
-		if err.Error() == "missing user_id" {
-			return nil, status.Error(codes.InvalidArgument, "missing user_id")
-		}
-		return nil, status.Errorf(codes.Internal, "get followees failed: %v", err)
+		return nil, apperr.ToStatus(err, "get followees")
 	}
 
-	return &followerpb.GetFolloweesResponse{UserIds: ids}, nil
+	return &followerpb.GetFolloweesResponse{
+		UserIds:    page.UserIDs,
+		TotalCount: page.TotalCount,
+		HasMore:    page.HasMore,
+	}, nil
+}
+
+func (h *FollowerHandler) GetFollowers(ctx context.Context, req *followerpb.GetFollowersRequest) (*followerpb.GetFollowersResponse, error) {
+	userID := req.GetUserId()
+	skip := int(req.GetSkip())
+	limit := int(req.GetLimit())
+
+	page, err := h.Svc.GetFollowers(ctx, userID, skip, limit)
+	if err != nil {
+		return nil, apperr.ToStatus(err, "get followers")
+	}
+
+	return &followerpb.GetFollowersResponse{
+		UserIds:    page.UserIDs,
+		TotalCount: page.TotalCount,
+		HasMore:    page.HasMore,
+	}, nil
 }
 
 func (h *FollowerHandler) GetRecommendations(ctx context.Context, req *followerpb.GetRecommendationsRequest) (*followerpb.GetRecommendationsResponse, error) {
 	userID := req.GetUserId()
 	limit := int(req.GetLimit())
 
-	recs, err := h.Svc.GetRecommendations(ctx, userID, limit)
+	set, err := h.Svc.GetRecommendations(ctx, userID, limit)
 	if err != nil {
-		if err.Error() == "missing user_id" {
-			return nil, status.Error(codes.InvalidArgument, "missing user_id")
-		}
-		return nil, status.Errorf(codes.Internal, "recommendations failed: %v", err)
+		return nil, apperr.ToStatus(err, "recommendations")
 	}
 
 	out := &followerpb.GetRecommendationsResponse{
-		Items: make([]*followerpb.Recommendation, 0, len(recs)),
+		Items:      make([]*followerpb.Recommendation, 0, len(set.Items)),
+		Source:     followerpb.RecommendationSource_RECOMMENDATION_SOURCE_LIVE,
+		ComputedAt: timestamppb.New(set.ComputedAt),
 	}
-	for _, r := range recs {
+	if set.Precomputed {
+		out.Source = followerpb.RecommendationSource_RECOMMENDATION_SOURCE_PRECOMPUTED
+	}
+	for _, r := range set.Items {
 		out.Items = append(out.Items, &followerpb.Recommendation{
 			UserId: r.UserID,
 			Mutual: r.Mutual,
+			Via:    r.Via,
 		})
 	}
 	return out, nil
 }
+
+func (h *FollowerHandler) GetFollowCounts(ctx context.Context, req *followerpb.GetFollowCountsRequest) (*followerpb.GetFollowCountsResponse, error) {
+	counts, err := h.Svc.GetFollowCounts(ctx, req.GetUserId())
+	if err != nil {
+		return nil, apperr.ToStatus(err, "get follow counts")
+	}
+	return &followerpb.GetFollowCountsResponse{
+		UserId:    counts.UserID,
+		Followers: counts.Followers,
+		Followees: counts.Followees,
+	}, nil
+}
+
+func (h *FollowerHandler) Block(ctx context.Context, req *followerpb.BlockRequest) (*emptypb.Empty, error) {
+	if err := h.Svc.Block(ctx, req.GetBlockerId(), req.GetBlockedId()); err != nil {
+		return nil, apperr.ToStatus(err, "block")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *FollowerHandler) Unblock(ctx context.Context, req *followerpb.UnblockRequest) (*emptypb.Empty, error) {
+	if err := h.Svc.Unblock(ctx, req.GetBlockerId(), req.GetBlockedId()); err != nil {
+		return nil, apperr.ToStatus(err, "unblock")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *FollowerHandler) GetFollowPolicy(ctx context.Context, req *followerpb.GetFollowPolicyRequest) (*followerpb.GetFollowPolicyResponse, error) {
+	p, err := h.Svc.GetFollowPolicy(ctx, req.GetUserId())
+	if err != nil {
+		return nil, apperr.ToStatus(err, "get follow policy")
+	}
+	return &followerpb.GetFollowPolicyResponse{
+		UserId:                p.UserID,
+		MaxFollowees:          p.MaxFollowees,
+		Followees:             p.Followees,
+		RemainingFollowees:    p.RemainingFollowees,
+		DailyFollowQuota:      p.DailyFollowQuota,
+		FollowsLastDay:        p.FollowsLastDay,
+		RemainingDailyFollows: p.RemainingDailyFollows,
+		ChurnWindowSeconds:    int64(p.ChurnWindow.Seconds()),
+		ChurnMaxChanges:       p.ChurnMaxChanges,
+	}, nil
+}
@@ -0,0 +1,33 @@
+// Package idempotency pamti prvi rezultat write RPC-a po idempotency ključu, da bi
+// ponovljeni zahtev (retry sa mobilnog klijenta) dobio isti odgovor umesto da se
+// ponovo izvrši. Store je interfejs da bi se mogao ubaciti deljeni store za više replika.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+
+// Record je zapamćen rezultat: ili Response ili Status (greška), nikad oba.
+type Record struct {
+	// RequestHash razlikuje ponovljen zahtev od drugog zahteva sa istim ključem
+	RequestHash string
+	Response    *anypb.Any
+	Status      *spb.Status
+}
+
+type Store interface {
+	// Reserve zauzima key na lease (dok se zahtev izvršava). Vraća zapamćen Record
+	// ako je zahtev već završen, odnosno ErrInProgress ako je još u toku.
+	Reserve(ctx context.Context, key string, lease time.Duration) (*Record, error)
+	// Complete upisuje rezultat i drži ga ttl.
+	Complete(ctx context.Context, key string, rec Record, ttl time.Duration) error
+	// Release oslobađa key bez rezultata (npr. posle greške servera), da retry ponovo izvrši zahtev.
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,86 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore čuva rezultate u memoriji jedne replike.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	record  *Record // nil dok je zahtev u toku
+	expires time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		entries: make(map[string]*entry),
+	}
+}
+
+func (s *MemoryStore) Reserve(_ context.Context, key string, lease time.Duration) (*Record, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok && now.Before(e.expires) {
+		if e.record == nil {
+			return nil, ErrInProgress
+		}
+		return e.record, nil
+	}
+	s.entries[key] = &entry{expires: now.Add(lease)}
+	return nil, nil
+}
+
+func (s *MemoryStore) Complete(_ context.Context, key string, rec Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = &entry{record: &rec, expires: s.now().Add(ttl)}
+	return nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// Sweep briše istekle unose.
+func (s *MemoryStore) Sweep() {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// RunSweeper poziva Sweep na svaki interval dok se ctx ne otkaže (worker.Group).
+func (s *MemoryStore) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Sweep()
+		}
+	}
+}
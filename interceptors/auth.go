@@ -0,0 +1,100 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+
+	"database-example/auth"
+	"database-example/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// AuthUnary postavlja auth.Principal u ctx za ostale interceptore i handler-e.
+// FollowerService metode (osim Ping-a) zahtevaju korisnika ili servis; servis
+// mora imati scope metode (auth.RequiredScope), a svaki njegov poziv ide u audit log.
+func AuthUnary(a *auth.Authenticator, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		// HTTP gateway već postavlja pozivaoca iz tokena koji je sam proverio
+		p, ok := auth.FromContext(ctx)
+		if !ok {
+			var err error
+			if p, err = a.Authenticate(ctx); err != nil {
+				// razlog (istekao token, nepoznat ključ...) ide samo u audit log
+				logging.FromContext(ctx, logger).Warn("authentication failed", "audit", true, "method", info.FullMethod, "error", err)
+				return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+			}
+		}
+		setCaller(ctx, p)
+		// metode sa scope-om su sve FollowerService metode osim Ping-a
+		scope := auth.RequiredScope(info.FullMethod)
+		if scope != "" && p.Kind == auth.KindAnonymous {
+			return nil, status.Error(codes.Unauthenticated, "authentication required")
+		}
+		ctx = auth.WithPrincipal(ctx, p)
+		if !p.IsService() {
+			if owner, ok := auth.Owner(req); ok && p.Kind == auth.KindUser && !p.Admin && owner != p.ID {
+				return nil, status.Error(codes.PermissionDenied, "users may only change their own follows and blocks")
+			}
+			return handler(ctx, req)
+		}
+
+		if scope != "" && !p.HasScope(scope) {
+			err := status.Errorf(codes.PermissionDenied, "service %q lacks scope %q", p.ID, scope)
+			audit(ctx, logger, p, info.FullMethod, req, err)
+			return nil, err
+		}
+		resp, err := handler(ctx, req)
+		audit(ctx, logger, p, info.FullMethod, req, err)
+		return resp, err
+	}
+}
+
+// audit beleži poziv servisa: pisanja i odbijeni pozivi na Info, čitanja na Debug.
+func audit(ctx context.Context, logger *slog.Logger, p auth.Principal, method string, req any, err error) {
+	code := status.Code(err)
+	level := slog.LevelDebug
+	if writeMethods[method] || code == codes.PermissionDenied {
+		level = slog.LevelInfo
+	}
+	attrs := []any{
+		"audit", true,
+		"service", p.ID,
+		"auth", p.Method,
+		"method", method,
+		"code", code.String(),
+	}
+	if m, ok := req.(proto.Message); ok {
+		attrs = append(attrs, "request", protojson.MarshalOptions{}.Format(m))
+	}
+	logging.FromContext(ctx, logger).Log(ctx, level, "service call", attrs...)
+}
+
+// principalKey: ključ pozivaoca iz AuthUnary ("" za anonimne).
+func principalKey(ctx context.Context) string {
+	p, _ := auth.FromContext(ctx)
+	return p.Key()
+}
+
+// callerHolder postavlja LoggingUnary (spolja), a popunjava AuthUnary, da bi
+// "rpc finished" log imao pozivaoca bez ponovne provere JWT-a.
+type callerHolder struct {
+	principal auth.Principal
+}
+
+type callerHolderKey struct{}
+
+func withCallerHolder(ctx context.Context) (context.Context, *callerHolder) {
+	h := &callerHolder{}
+	return context.WithValue(ctx, callerHolderKey{}, h), h
+}
+
+func setCaller(ctx context.Context, p auth.Principal) {
+	if h, ok := ctx.Value(callerHolderKey{}).(*callerHolder); ok {
+		h.principal = p
+	}
+}
@@ -0,0 +1,170 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"database-example/auth"
+	"database-example/config"
+	followerpb "database-example/proto/follower"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func newAuthInterceptor(t *testing.T) grpc.UnaryServerInterceptor {
+	t.Helper()
+	sum := sha256.Sum256([]byte("feed-key"))
+	a, err := auth.New(config.ServiceAuthConfig{
+		Role: "service",
+		APIKeys: []config.ServiceAPIKey{{
+			Name:      "feed",
+			KeySHA256: hex.EncodeToString(sum[:]),
+			Scopes:    []string{auth.ScopeFollowsRead},
+		}},
+	}, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return AuthUnary(a, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestAuthUnary(t *testing.T) {
+	follow := &followerpb.FollowRequest{FollowerId: "alice", FolloweeId: "bob"}
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    any
+		want   codes.Code
+	}{
+		{
+			name:   "anonymous ping",
+			ctx:    context.Background(),
+			method: followerpb.FollowerService_Ping_FullMethodName,
+			req:    &followerpb.PingRequest{},
+			want:   codes.OK,
+		},
+		{
+			name:   "anonymous read",
+			ctx:    context.Background(),
+			method: followerpb.FollowerService_GetFollowers_FullMethodName,
+			req:    &followerpb.GetFollowersRequest{UserId: "alice"},
+			want:   codes.Unauthenticated,
+		},
+		{
+			name:   "unknown api key",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APIKeyHeader, "wrong")),
+			method: followerpb.FollowerService_Ping_FullMethodName,
+			req:    &followerpb.PingRequest{},
+			want:   codes.Unauthenticated,
+		},
+		{
+			name:   "service with scope",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APIKeyHeader, "feed-key")),
+			method: followerpb.FollowerService_GetFollowers_FullMethodName,
+			req:    &followerpb.GetFollowersRequest{UserId: "alice"},
+			want:   codes.OK,
+		},
+		{
+			name:   "service without scope",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APIKeyHeader, "feed-key")),
+			method: followerpb.FollowerService_Follow_FullMethodName,
+			req:    follow,
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "service JWT without scope",
+			ctx:    auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindService, ID: "search", Method: auth.MethodJWT}),
+			method: followerpb.FollowerService_GetFollowers_FullMethodName,
+			req:    &followerpb.GetFollowersRequest{UserId: "alice"},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "user acting for themselves",
+			ctx:    auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindUser, ID: "alice"}),
+			method: followerpb.FollowerService_Follow_FullMethodName,
+			req:    follow,
+			want:   codes.OK,
+		},
+		{
+			name:   "user acting for someone else",
+			ctx:    auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindUser, ID: "mallory"}),
+			method: followerpb.FollowerService_Follow_FullMethodName,
+			req:    follow,
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "admin acting for someone else",
+			ctx:    auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindUser, ID: "root", Admin: true}),
+			method: followerpb.FollowerService_Block_FullMethodName,
+			req:    &followerpb.BlockRequest{BlockerId: "alice", BlockedId: "bob"},
+			want:   codes.OK,
+		},
+		{
+			name:   "user reading someone else",
+			ctx:    auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindUser, ID: "mallory"}),
+			method: followerpb.FollowerService_GetFollowers_FullMethodName,
+			req:    &followerpb.GetFollowersRequest{UserId: "alice"},
+			want:   codes.OK,
+		},
+	}
+
+	interceptor := newAuthInterceptor(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				if _, ok := auth.FromContext(ctx); !ok {
+					t.Error("handler called without principal in ctx")
+				}
+				return &emptypb.Empty{}, nil
+			}
+			_, err := interceptor(tt.ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %v, want %v (%v)", got, tt.want, err)
+			}
+			if called != (tt.want == codes.OK) {
+				t.Errorf("handler called = %v, want %v", called, tt.want == codes.OK)
+			}
+		})
+	}
+}
+
+// Klijent dobija fiksnu poruku; razlog odbijanja ostaje samo u audit logu.
+func TestAuthUnaryHidesVerifierError(t *testing.T) {
+	a, err := auth.New(config.ServiceAuthConfig{Role: "service"}, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	interceptor := AuthUnary(a, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	for _, md := range []metadata.MD{
+		metadata.Pairs(auth.APIKeyHeader, "wrong"),
+		metadata.Pairs("authorization", "Bearer not.a.jwt"),
+	} {
+		logs.Reset()
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		_, err := interceptor(ctx, &followerpb.PingRequest{}, &grpc.UnaryServerInfo{FullMethod: followerpb.FollowerService_Ping_FullMethodName},
+			func(ctx context.Context, req any) (any, error) { return &emptypb.Empty{}, nil })
+
+		st := status.Convert(err)
+		if st.Code() != codes.Unauthenticated || st.Message() != "invalid credentials" {
+			t.Errorf("%v: got %v %q, want Unauthenticated %q", md, st.Code(), st.Message(), "invalid credentials")
+		}
+		if !strings.Contains(logs.String(), "authentication failed") || !strings.Contains(logs.String(), "invalid") {
+			t.Errorf("%v: audit log missing failure detail: %s", md, logs.String())
+		}
+	}
+}
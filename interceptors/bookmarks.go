@@ -0,0 +1,46 @@
+package interceptors
+
+import (
+	"context"
+
+	"database-example/apperr"
+	"database-example/repo"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// BookmarkHeader je metadata ključ za Neo4j bookmark: write RPC ga vraća u
+// header-u, a klijent ga šalje uz čitanje da bi video sopstveni upis.
+const BookmarkHeader = "neo4j-bookmark"
+
+// granice za bookmarke iz metadata, da klijent ne bi slao proizvoljno velike vrednosti
+const (
+	maxBookmarks      = 16
+	maxBookmarkLength = 1024
+)
+
+var errInvalidBookmark = apperr.Invalid("INVALID_BOOKMARK", "invalid neo4j bookmark")
+
+func BookmarksUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		in := metadata.ValueFromIncomingContext(ctx, BookmarkHeader)
+		if len(in) > maxBookmarks {
+			return nil, errInvalidBookmark.WithField(BookmarkHeader, "too many bookmarks").GRPCStatus().Err()
+		}
+		for _, b := range in {
+			if b == "" || len(b) > maxBookmarkLength {
+				return nil, errInvalidBookmark.WithField(BookmarkHeader, "must be a non-empty bookmark from a previous response").GRPCStatus().Err()
+			}
+		}
+
+		ctx, tracker := repo.WithBookmarks(ctx, in)
+		resp, err := handler(ctx, req)
+		if err == nil {
+			if out := tracker.Last(); len(out) > 0 {
+				_ = grpc.SetHeader(ctx, metadata.MD{BookmarkHeader: out})
+			}
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,22 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ChainUnary spaja interceptore u jedan, istim redosledom kao grpc.ChainUnaryInterceptor.
+// Koristi ga HTTP gateway da bi njegovi pozivi prošli isti lanac kao gRPC.
+func ChainUnary(list ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(list) - 1; i >= 0; i-- {
+			ic, h := list[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return ic(ctx, req, info, h)
+			}
+		}
+		return next(ctx, req)
+	}
+}
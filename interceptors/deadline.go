@@ -0,0 +1,45 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+
+	"database-example/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeadlineUnary postavlja rok iz konfiguracije kad ga klijent nije poslao, da
+// Neo4j upit ne bi trajao duže nego što iko čeka odgovor. Rok klijenta se poštuje
+// i kad je duži od podrazumevanog.
+func DeadlineUnary(cfg config.TimeoutConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			_, method := splitMethod(info.FullMethod)
+			if timeout := cfg.For(method); timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+		}
+		resp, err := handler(ctx, req)
+		return resp, contextError(ctx, err)
+	}
+}
+
+// contextError: drajver ne wrap-uje uvek ctx grešku, pa kad je ctx istekao ili
+// otkazan, a handler vrati Internal/Unknown, klijent dobija DeadlineExceeded/Canceled.
+func contextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if c := status.Code(err); c != codes.Internal && c != codes.Unknown {
+		return err
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	}
+	return status.Error(codes.Canceled, "request canceled")
+}
@@ -0,0 +1,142 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"database-example/config"
+	"database-example/metrics"
+	followerpb "database-example/proto/follower"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var getFollowersInfo = &grpc.UnaryServerInfo{FullMethod: followerpb.FollowerService_GetFollowers_FullMethodName}
+
+// waitThen čeka kraj ctx-a pa vraća grešku kakvu vraća drajver koji ne wrap-uje ctx grešku.
+func waitThen(code codes.Code) grpc.UnaryHandler {
+	return func(ctx context.Context, req any) (any, error) {
+		<-ctx.Done()
+		return nil, status.Error(code, "neo4j: connection closed")
+	}
+}
+
+func TestDeadlineUnaryMapsContextErrors(t *testing.T) {
+	interceptor := DeadlineUnary(config.TimeoutConfig{Default: 10 * time.Millisecond})
+
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		handler grpc.UnaryHandler
+		want    codes.Code
+	}{
+		{
+			name:    "internal after configured deadline",
+			ctx:     func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			handler: waitThen(codes.Internal),
+			want:    codes.DeadlineExceeded,
+		},
+		{
+			name:    "unknown after configured deadline",
+			ctx:     func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			handler: waitThen(codes.Unknown),
+			want:    codes.DeadlineExceeded,
+		},
+		{
+			name: "internal after client cancel",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			handler: waitThen(codes.Internal),
+			want:    codes.Canceled,
+		},
+		{
+			name:    "domain error is kept",
+			ctx:     func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			handler: waitThen(codes.NotFound),
+			want:    codes.NotFound,
+		},
+		{
+			name: "internal before deadline is kept",
+			ctx:  func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			handler: func(ctx context.Context, req any) (any, error) {
+				return nil, status.Error(codes.Internal, "boom")
+			},
+			want: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			_, err := interceptor(ctx, &followerpb.GetFollowersRequest{}, getFollowersInfo, tt.handler)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %v, want %v (%v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDeadlineUnaryRespectsClientDeadline(t *testing.T) {
+	interceptor := DeadlineUnary(config.TimeoutConfig{
+		Default: time.Second,
+		Methods: map[string]time.Duration{"GetFollowers": time.Millisecond},
+	})
+
+	var got time.Duration
+	handler := func(ctx context.Context, req any) (any, error) {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("handler ctx has no deadline")
+		}
+		got = time.Until(deadline)
+		return nil, nil
+	}
+
+	// rok po metodi kad ga klijent nije poslao
+	if _, err := interceptor(context.Background(), nil, getFollowersInfo, handler); err != nil {
+		t.Fatal(err)
+	}
+	if got > time.Millisecond {
+		t.Errorf("method deadline = %v, want <= 1ms", got)
+	}
+
+	// duži rok klijenta se ne skraćuje
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	if _, err := interceptor(ctx, nil, getFollowersInfo, handler); err != nil {
+		t.Fatal(err)
+	}
+	if got < 59*time.Minute {
+		t.Errorf("client deadline shortened to %v", got)
+	}
+}
+
+// Redosled iz main.go: MetricsUnary spolja, pa metrika vidi kod posle mapiranja.
+func TestMetricsRecordMappedDeadline(t *testing.T) {
+	chain := ChainUnary(
+		MetricsUnary(),
+		DeadlineUnary(config.TimeoutConfig{Default: 10 * time.Millisecond}),
+	)
+	service, method := splitMethod(getFollowersInfo.FullMethod)
+	deadline := metrics.RPCHandled.WithLabelValues(service, method, codes.DeadlineExceeded.String())
+	internal := metrics.RPCHandled.WithLabelValues(service, method, codes.Internal.String())
+	beforeDeadline, beforeInternal := testutil.ToFloat64(deadline), testutil.ToFloat64(internal)
+
+	_, err := chain(context.Background(), &followerpb.GetFollowersRequest{}, getFollowersInfo, waitThen(codes.Internal))
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("code = %v, want DeadlineExceeded", status.Code(err))
+	}
+	if d := testutil.ToFloat64(deadline) - beforeDeadline; d != 1 {
+		t.Errorf("DeadlineExceeded counter += %v, want 1", d)
+	}
+	if d := testutil.ToFloat64(internal) - beforeInternal; d != 0 {
+		t.Errorf("Internal counter += %v, want 0", d)
+	}
+}
@@ -0,0 +1,160 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"database-example/idempotency"
+	"database-example/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// IdempotencyKeyHeader šalje klijent uz write RPC (Follow, Unfollow, Block, Unblock).
+	IdempotencyKeyHeader = "idempotency-key"
+	// IdempotentReplayHeader je "true" u odgovoru kada je rezultat ponovljen iz store-a.
+	IdempotentReplayHeader = "idempotent-replayed"
+
+	maxIdempotencyKeyLen = 128
+	// idempotencyLease je koliko dugo je ključ zauzet dok se zahtev izvršava
+	idempotencyLease = time.Minute
+)
+
+// IdempotencyUnary pamti prvi rezultat write RPC-a na ttl i ponavlja ga za retry-e
+// sa istim idempotency-key. Ključ važi samo u okviru autentifikovanog korisnika
+// ili servisa; bez identiteta ili bez header-a zahtev se izvršava normalno.
+func IdempotencyUnary(store idempotency.Store, ttl time.Duration, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !writeMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		key := idempotencyKey(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		}
+		caller := principalKey(ctx)
+		if caller == "" {
+			return handler(ctx, req)
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			return nil, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLen)
+		}
+
+		hash, err := requestHash(info.FullMethod, req)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "hash request: %v", err)
+		}
+		storeKey := caller + "\x00" + key
+		log := logging.FromContext(ctx, logger)
+
+		rec, err := store.Reserve(ctx, storeKey, idempotencyLease)
+		switch {
+		case errors.Is(err, idempotency.ErrInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case err != nil:
+			// store nije dostupan: izvrši zahtev bez zaštite umesto da ga odbiješ
+			log.Warn("idempotency store unavailable, executing request", "method", info.FullMethod, "error", err)
+			return handler(ctx, req)
+		case rec != nil:
+			return replay(ctx, rec, hash)
+		}
+
+		resp, err := handler(ctx, req)
+
+		// prolazne greške se ne pamte, retry treba ponovo da pokuša; ctx je možda
+		// već otkazan (Canceled), a ključ i dalje treba osloboditi
+		if isRetryable(status.Code(err)) {
+			if relErr := store.Release(context.WithoutCancel(ctx), storeKey); relErr != nil {
+				log.Warn("failed to release idempotency key", "error", relErr)
+			}
+			return resp, err
+		}
+		rec, recErr := newRecord(hash, resp, err)
+		if recErr == nil {
+			recErr = store.Complete(ctx, storeKey, *rec, ttl)
+		}
+		if recErr != nil {
+			log.Warn("failed to store idempotent result", "method", info.FullMethod, "error", recErr)
+		}
+		return resp, err
+	}
+}
+
+// isRetryable: greške servera, odbijanje rate limita (ResourceExhausted),
+// klijent koji je prekinuo vezu (Canceled) i konflikti (Aborted) nisu konačan
+// ishod zahteva, pa ih ne treba ponavljati iz store-a.
+func isRetryable(code codes.Code) bool {
+	switch code {
+	case codes.ResourceExhausted, codes.Canceled, codes.Aborted:
+		return true
+	}
+	return isServerError(code)
+}
+
+func idempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if vals := md.Get(IdempotencyKeyHeader); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// requestHash uključuje i metodu, da isti ključ za Follow i Unfollow ne bi bio "isti zahtev".
+func requestHash(method string, req any) (string, error) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return "", errors.New("request is not a proto message")
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(method+"\x00"), body...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func newRecord(hash string, resp any, err error) (*idempotency.Record, error) {
+	rec := &idempotency.Record{RequestHash: hash}
+	if err != nil {
+		rec.Status = status.Convert(err).Proto()
+		return rec, nil
+	}
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, errors.New("response is not a proto message")
+	}
+	anyResp, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	rec.Response = anyResp
+	return rec, nil
+}
+
+func replay(ctx context.Context, rec *idempotency.Record, hash string) (any, error) {
+	if rec.RequestHash != hash {
+		return nil, status.Errorf(codes.InvalidArgument, "%s was already used for a different request", IdempotencyKeyHeader)
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayHeader, "true"))
+	if rec.Status != nil {
+		return nil, status.ErrorProto(rec.Status)
+	}
+	resp, err := rec.Response.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "replay stored response: %v", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,75 @@
+package interceptors
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"database-example/auth"
+	"database-example/idempotency"
+	followerpb "database-example/proto/follower"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Prolazne greške (rate limit, prekinut klijent, konflikt) ne smeju da se pamte:
+// retry sa istim ključem mora ponovo da izvrši zahtev.
+func TestIdempotencyDoesNotStoreRetryableErrors(t *testing.T) {
+	for _, code := range []codes.Code{codes.ResourceExhausted, codes.Canceled, codes.Aborted, codes.Unavailable} {
+		t.Run(code.String(), func(t *testing.T) {
+			interceptor := IdempotencyUnary(idempotency.NewMemoryStore(), time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			calls := 0
+			handler := func(ctx context.Context, req any) (any, error) {
+				calls++
+				if calls == 1 {
+					return nil, status.Error(code, "transient")
+				}
+				return &emptypb.Empty{}, nil
+			}
+
+			if _, err := callFollow(interceptor, "b", handler); status.Code(err) != code {
+				t.Fatalf("first call: got %v, want %v", status.Code(err), code)
+			}
+			if _, err := callFollow(interceptor, "b", handler); err != nil {
+				t.Fatalf("retry with the same key: got %v, want OK", err)
+			}
+			if calls != 2 {
+				t.Fatalf("handler called %d times, want 2", calls)
+			}
+		})
+	}
+}
+
+// Konačan ishod (i domenska greška) se ponavlja iz store-a bez izvršavanja.
+func TestIdempotencyReplaysFinalResult(t *testing.T) {
+	interceptor := IdempotencyUnary(idempotency.NewMemoryStore(), time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	calls := 0
+	handler := func(ctx context.Context, req any) (any, error) {
+		calls++
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	for range 2 {
+		if _, err := callFollow(interceptor, "b", handler); status.Code(err) != codes.NotFound {
+			t.Fatalf("got %v, want NotFound", status.Code(err))
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+}
+
+func callFollow(interceptor grpc.UnaryServerInterceptor, key string, handler grpc.UnaryHandler) (any, error) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, key))
+	ctx = auth.WithPrincipal(ctx, auth.Principal{Kind: auth.KindUser, ID: "u1"})
+	info := &grpc.UnaryServerInfo{FullMethod: followerpb.FollowerService_Follow_FullMethodName}
+	return interceptor(ctx, &followerpb.FollowRequest{FollowerId: "u1", FolloweeId: "u2"}, info, handler)
+}
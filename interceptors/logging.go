@@ -0,0 +1,95 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"database-example/auth"
+	"database-example/logging"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// LoggingUnary ide odmah posle TracingUnary, pre ostalih: dodeljuje request ID
+// (iz x-request-id metadata ili novi), vraća ga klijentu u header-u i loguje
+// ishod svakog RPC-a. Pozivaoca u log upisuje AuthUnary (vidi callerHolder).
+func LoggingUnary(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = withRequestID(ctx)
+		ctx, caller := withCallerHolder(ctx)
+		resp, err := handler(ctx, req)
+		logRPC(ctx, logger, info.FullMethod, start, err, caller.principal)
+		return resp, err
+	}
+}
+
+func LoggingStream(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := withRequestID(ss.Context())
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+		logRPC(ctx, logger, info.FullMethod, start, err, auth.Principal{})
+		return err
+	}
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(logging.RequestIDKey); len(vals) > 0 {
+			id = vals[0]
+		}
+	}
+	if id == "" {
+		id = uuid.NewString()
+	}
+	// u HTTP gateway-u request ID u odgovor upisuje sam gateway, pa se ovaj header tamo ne prepisuje
+	_ = grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIDKey, id))
+	return logging.WithRequestID(ctx, id)
+}
+
+func logRPC(ctx context.Context, logger *slog.Logger, method string, start time.Time, err error, caller auth.Principal) {
+	code := status.Code(err)
+	attrs := []any{
+		"method", method,
+		"code", code.String(),
+		"duration", time.Since(start),
+	}
+	switch caller.Kind {
+	case auth.KindUser:
+		attrs = append(attrs, "user_id", caller.ID)
+	case auth.KindService:
+		attrs = append(attrs, "service", caller.ID)
+	}
+	if err != nil {
+		attrs = append(attrs, "error", status.Convert(err).Message())
+	}
+
+	logging.FromContext(ctx, logger).Log(ctx, rpcLogLevel(code), "rpc finished", attrs...)
+}
+
+// Greške servera su Error, greške klijenta Warn, uspeh Info.
+func rpcLogLevel(code codes.Code) slog.Level {
+	switch {
+	case code == codes.OK:
+		return slog.LevelInfo
+	case isServerError(code):
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded, codes.Unimplemented:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package interceptors
+
+import (
+	followerpb "database-example/proto/follower"
+)
+
+// writeMethods su FollowerService metode koje menjaju graf.
+var writeMethods = map[string]bool{
+	followerpb.FollowerService_Follow_FullMethodName:   true,
+	followerpb.FollowerService_Unfollow_FullMethodName: true,
+	followerpb.FollowerService_Block_FullMethodName:    true,
+	followerpb.FollowerService_Unblock_FullMethodName:  true,
+}
@@ -0,0 +1,47 @@
+// Package interceptors sadrži gRPC interceptore koji se primenjuju na sve RPC-ove
+// (i na pozive iz HTTP gateway-a, preko ChainUnary).
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"database-example/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func MetricsUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func MetricsStream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeRPC(fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	metrics.RPCDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+	metrics.RPCHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+}
+
+// splitMethod: "/follower.FollowerService/Follow" -> ("follower.FollowerService", "Follow")
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
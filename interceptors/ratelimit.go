@@ -0,0 +1,82 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"database-example/logging"
+	"database-example/metrics"
+	followerpb "database-example/proto/follower"
+	"database-example/ratelimit"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterKey je header metadata sa brojem sekundi do sledećeg pokušaja.
+const RetryAfterKey = "retry-after"
+
+// RateLimitUnary ograničava FollowerService pozive po korisniku iz JWT-a ili servisu, odnosno
+// po IP adresi za anonimne pozive. writeMethods troše budžet za pisanje, ostale
+// metode budžet za čitanje; health, reflection i Ping nisu ograničeni.
+func RateLimitUnary(writes, reads ratelimit.Limiter, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		service, method := splitMethod(info.FullMethod)
+		if service != followerpb.FollowerService_ServiceDesc.ServiceName || info.FullMethod == followerpb.FollowerService_Ping_FullMethodName {
+			return handler(ctx, req)
+		}
+
+		limiter, kind := reads, "read"
+		if writeMethods[info.FullMethod] {
+			limiter, kind = writes, "write"
+		}
+
+		allowed, retryAfter, err := limiter.Allow(ctx, callerKey(ctx))
+		if err != nil {
+			// ako store ne radi, bolje je pustiti zahtev nego oboriti servis
+			logging.FromContext(ctx, logger).Warn("rate limiter unavailable, allowing request", "method", info.FullMethod, "error", err)
+			return handler(ctx, req)
+		}
+		if !allowed {
+			metrics.RateLimited.WithLabelValues(method, kind).Inc()
+			return nil, rateLimitedError(ctx, retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// callerKey: "user:<id>" ili "service:<ime>" za autentifikovane, "ip:<adresa>" za ostale.
+func callerKey(ctx context.Context) string {
+	if key := principalKey(ctx); key != "" {
+		return key
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "ip:" + host
+	}
+	return "anonymous"
+}
+
+func rateLimitedError(ctx context.Context, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterKey, strconv.Itoa(seconds)))
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded, retry after %ds", seconds))
+	if withInfo, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
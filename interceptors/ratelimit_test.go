@@ -0,0 +1,112 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"database-example/auth"
+	followerpb "database-example/proto/follower"
+	"database-example/ratelimit"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// stubLimiter vraća zadat odgovor i pamti ključeve.
+type stubLimiter struct {
+	allowed    bool
+	retryAfter time.Duration
+	err        error
+	keys       []string
+}
+
+func (l *stubLimiter) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	l.keys = append(l.keys, key)
+	return l.allowed, l.retryAfter, l.err
+}
+
+var _ ratelimit.Limiter = (*stubLimiter)(nil)
+
+func callAs(interceptor grpc.UnaryServerInterceptor, userID, method string) error {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindUser, ID: userID})
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req any) (any, error) { return &emptypb.Empty{}, nil })
+	return err
+}
+
+func TestRateLimitSeparateBudgets(t *testing.T) {
+	writes := ratelimit.NewMemoryLimiter(ratelimit.Limit{RequestsPerMinute: 1, Burst: 1})
+	reads := ratelimit.NewMemoryLimiter(ratelimit.Limit{RequestsPerMinute: 1, Burst: 2})
+	interceptor := RateLimitUnary(writes, reads, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	follow := followerpb.FollowerService_Follow_FullMethodName
+	read := followerpb.FollowerService_GetFollowers_FullMethodName
+
+	if err := callAs(interceptor, "alice", follow); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	if err := callAs(interceptor, "alice", followerpb.FollowerService_Block_FullMethodName); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second write: %v, want ResourceExhausted", err)
+	}
+	// potrošen budžet za pisanje ne dira čitanje
+	for i := range 2 {
+		if err := callAs(interceptor, "alice", read); err != nil {
+			t.Fatalf("read %d: %v", i+1, err)
+		}
+	}
+	if err := callAs(interceptor, "alice", read); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("third read: %v, want ResourceExhausted", err)
+	}
+	// drugi korisnik ima svoj budžet; Ping nije ograničen
+	if err := callAs(interceptor, "bob", follow); err != nil {
+		t.Fatalf("bob write: %v", err)
+	}
+	for range 5 {
+		if err := callAs(interceptor, "alice", followerpb.FollowerService_Ping_FullMethodName); err != nil {
+			t.Fatalf("ping: %v", err)
+		}
+	}
+}
+
+func TestRateLimitRetryInfo(t *testing.T) {
+	limiter := &stubLimiter{retryAfter: 1200 * time.Millisecond}
+	interceptor := RateLimitUnary(limiter, limiter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err := callAs(interceptor, "alice", followerpb.FollowerService_Follow_FullMethodName)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("code = %v, want ResourceExhausted", st.Code())
+	}
+	// poruka zaokružuje naviše, RetryInfo nosi tačno trajanje
+	if want := "rate limit exceeded, retry after 2s"; st.Message() != want {
+		t.Errorf("message = %q, want %q", st.Message(), want)
+	}
+	var info *errdetails.RetryInfo
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			info = ri
+		}
+	}
+	if info == nil || info.GetRetryDelay().AsDuration() != 1200*time.Millisecond {
+		t.Errorf("RetryInfo = %v, want 1.2s", info)
+	}
+	if len(limiter.keys) != 1 || limiter.keys[0] != "user:alice" {
+		t.Errorf("limiter keys = %v, want [user:alice]", limiter.keys)
+	}
+}
+
+func TestRateLimitFailsOpen(t *testing.T) {
+	limiter := &stubLimiter{err: errors.New("redis down")}
+	interceptor := RateLimitUnary(limiter, limiter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := callAs(interceptor, "alice", followerpb.FollowerService_Follow_FullMethodName); err != nil {
+		t.Fatalf("limiter error should allow the request, got %v", err)
+	}
+}
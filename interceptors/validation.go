@@ -0,0 +1,21 @@
+package interceptors
+
+import (
+	"context"
+
+	"database-example/apperr"
+	"database-example/validation"
+
+	"google.golang.org/grpc"
+)
+
+// ValidationUnary odbija neispravne zahteve sa InvalidArgument i
+// google.rpc.BadRequest detaljima, pre nego što stignu do handler-a.
+func ValidationUnary(v *validation.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := v.Validate(req); err != nil {
+			return nil, apperr.ToStatus(err, info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,84 @@
+// Package logging pravi strukturisani (JSON) slog logger servisa i prenosi
+// request ID kroz context do repozitorijuma.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	neo4jlog "github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDKey je gRPC metadata (i HTTP header) ključ za korelaciju zahteva.
+const RequestIDKey = "x-request-id"
+
+func New(w io.Writer, level string) *slog.Logger {
+	h := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: ParseLevel(level)})
+	return slog.New(h).With("service", "follower-service")
+}
+
+// ParseLevel: debug, info, warn, error (nepoznato = info).
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext vraća base logger obogaćen request ID-jem i trace ID-jem iz ctx
+// (ako postoje), da bi se log linije mogle povezati sa trace-om.
+func FromContext(ctx context.Context, base *slog.Logger) *slog.Logger {
+	logger := base
+	if id := RequestID(ctx); id != "" {
+		logger = logger.With("request_id", id)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		logger = logger.With("trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+	return logger
+}
+
+// Neo4jLogger prosleđuje logove Neo4j drajvera u slog.
+type Neo4jLogger struct {
+	Logger *slog.Logger
+}
+
+var _ neo4jlog.Logger = Neo4jLogger{}
+
+func (l Neo4jLogger) Error(name, id string, err error) {
+	l.Logger.Error("neo4j driver error", "component", name, "component_id", id, "error", err)
+}
+
+func (l Neo4jLogger) Warnf(name, id string, msg string, args ...any) {
+	l.Logger.Warn(fmt.Sprintf(msg, args...), "component", name, "component_id", id)
+}
+
+// Infof drajvera (svaka nova konekcija...) je za nas debug nivo.
+func (l Neo4jLogger) Infof(name, id string, msg string, args ...any) {
+	l.Logger.Debug(fmt.Sprintf(msg, args...), "component", name, "component_id", id)
+}
+
+func (l Neo4jLogger) Debugf(name, id string, msg string, args ...any) {
+	l.Logger.Debug(fmt.Sprintf(msg, args...), "component", name, "component_id", id)
+}
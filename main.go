@@ -3,67 +3,386 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"flag"
+	"log/slog"
+	"math"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"database-example/auth"
+	"database-example/cache"
+	"database-example/config"
+	"database-example/gateway"
 	"database-example/handlers"
+	"database-example/idempotency"
+	"database-example/interceptors"
+	"database-example/logging"
+	"database-example/metrics"
 	followerpb "database-example/proto/follower"
+	"database-example/ratelimit"
+	"database-example/recommendations"
 	"database-example/repo"
 	"database-example/service"
+	"database-example/tlsreload"
+	"database-example/tracing"
+	"database-example/util"
+	"database-example/validation"
+	"database-example/worker"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	logger := log.New(os.Stdout, "[follower-service] ", log.LstdFlags)
+	// admin podkomande: follower-service export|import [flags]
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export", "import":
+			// logovi idu na stderr da ne bi mešali export na stdout
+			logger := logging.New(os.Stderr, os.Getenv("LOG_LEVEL"))
+			os.Exit(runAdmin(logger, os.Args[1], os.Args[2:]))
+		}
+	}
+
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "optional YAML/JSON config file")
+	migrateOnly := flag.Bool("migrate-only", false, "apply Neo4j schema migrations and exit")
+	flag.Parse()
+
+	// --- Konfiguracija ---
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fatal(logging.New(os.Stdout, "info"), "invalid configuration", err)
+	}
+	logger := logging.New(os.Stdout, cfg.LogLevel)
+	if err := util.ConfigureJWT(cfg.JWT, logger); err != nil {
+		fatal(logger, "failed to configure JWT verification", err)
+	}
+	authenticator, err := auth.New(cfg.ServiceAuth, cfg.JWT.AdminRole)
+	if err != nil {
+		fatal(logger, "failed to configure service authentication", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// --- Tracing (OpenTelemetry) ---
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		fatal(logger, "failed to set up tracing", err)
+	}
 
 	// --- Repo sloj (Neo4j) ---
-	followerRepo, err := repo.NewFollowerRepository(logger)
+	followerRepo, err := repo.NewFollowerRepository(cfg, logger)
 	if err != nil {
-		logger.Fatal("Failed to connect to Neo4j:", err)
+		fatal(logger, "failed to create Neo4j driver", err)
+	}
+	if *migrateOnly {
+		// Connect primenjuje i migracije šeme
+		err := followerRepo.Connect(ctx)
+		followerRepo.Close(context.Background())
+		shutdownTracing(context.Background())
+		if err != nil {
+			fatal(logger, "schema migration failed", err)
+		}
+		logger.Info("schema migrations are up to date")
+		return
 	}
-	// ako imaš r.Close(ctx) u repou:
-	defer followerRepo.Close(context.Background())
+
+	// pozadinski workeri (keševi, relay-evi...) se gase pre zatvaranja drajvera
+	workers := worker.NewGroup(logger)
 
 	// --- Service sloj ---
 	followSvc := &service.FollowerService{
 		FollowerRepo: followerRepo,
+		Policy:       cfg.FollowPolicy,
+		Pages:        cfg.Pagination,
+	}
+	if cfg.Cache.Enabled {
+		followSvc.Cache = cache.NewLRU(cfg.Cache.Size, cfg.Cache.TTL)
+	}
+	if cfg.Recommendations.Precompute {
+		materializer := recommendations.New(followerRepo, cfg.Recommendations, logger)
+		materializer.OnRefresh = followSvc.InvalidateRecommendations
+		followSvc.Recs = cfg.Recommendations
+		followSvc.RecsQueue = materializer
+		workers.Go("recommendations-materializer", func(ctx context.Context) {
+			// čeka da Connect završi, da prvi prolaz ne bi padao dok baza nije gore
+			if !waitReady(ctx, followerRepo) {
+				return
+			}
+			materializer.Run(ctx)
+		})
 	}
 
 	// --- Handler sloj ---
 	followHandler := handlers.NewFollowerHandler(followSvc)
 
-	// --- gRPC server ---
-	addr := os.Getenv("FOLLOWER_SERVICE_ADDRESS")
-	if addr == "" {
-		addr = ":50051"
+	// --- Interceptori (isti lanac i za HTTP gateway) ---
+	// tracing pa logging prvi, da bi trace i request ID bili u ctx za sve ostale
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		interceptors.TracingUnary(),
+		interceptors.LoggingUnary(logger),
+		// metrike spolja: beleže kod posle DeadlineUnary (DeadlineExceeded, ne Internal)
+		interceptors.MetricsUnary(),
+		interceptors.DeadlineUnary(cfg.Timeouts),
+		// pre idempotency i rate limita: oba ključuju po pozivaocu (korisnik ili servis)
+		interceptors.AuthUnary(authenticator, logger),
+	}
+	if cfg.RateLimit.Enabled {
+		writeLimiter := ratelimit.NewMemoryLimiter(ratelimit.Limit(cfg.RateLimit.Write))
+		readLimiter := ratelimit.NewMemoryLimiter(ratelimit.Limit(cfg.RateLimit.Read))
+		workers.Go("ratelimit-sweeper-write", func(ctx context.Context) {
+			writeLimiter.RunSweeper(ctx, time.Minute)
+		})
+		workers.Go("ratelimit-sweeper-read", func(ctx context.Context) {
+			readLimiter.RunSweeper(ctx, time.Minute)
+		})
+		unaryInterceptors = append(unaryInterceptors, interceptors.RateLimitUnary(writeLimiter, readLimiter, logger))
+	}
+	// idempotency posle rate limita: odbijen zahtev ne sme da zauzme ključ
+	if cfg.Idempotency.Enabled {
+		idempotencyStore := idempotency.NewMemoryStore()
+		workers.Go("idempotency-sweeper", func(ctx context.Context) {
+			idempotencyStore.RunSweeper(ctx, time.Minute)
+		})
+		unaryInterceptors = append(unaryInterceptors, interceptors.IdempotencyUnary(idempotencyStore, cfg.Idempotency.TTL, logger))
+	}
+	// validacija poslednja: i neispravni zahtevi troše rate limit budžet
+	// sa ClampLimit servis smanjuje prevelik limit, inače ga validacija odbija
+	maxLimit := int32(math.MaxInt32)
+	if !cfg.Pagination.ClampLimit {
+		maxLimit = int32(cfg.Pagination.MaxPageSize)
+	}
+	validator := validation.New(validation.IDFormat(cfg.Validation.IDFormat), maxLimit)
+	unaryInterceptors = append(unaryInterceptors,
+		interceptors.ValidationUnary(validator),
+		interceptors.BookmarksUnary(),
+	)
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		interceptors.TracingStream(),
+		interceptors.LoggingStream(logger),
+		interceptors.MetricsStream(),
 	}
 
+	// --- gRPC server ---
+	addr := cfg.Address
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
-		logger.Fatal("Failed to listen:", err)
+		fatal(logger, "failed to listen", err, "address", addr)
 	}
 
-	grpcServer := grpc.NewServer()
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+	if cfg.TLS.Enabled() {
+		certs, err := tlsreload.New(tlsreload.Options{
+			CertFile:     cfg.TLS.CertFile,
+			KeyFile:      cfg.TLS.KeyFile,
+			ClientCAFile: cfg.TLS.ClientCAFile,
+			ClientAuth:   cfg.TLS.ClientAuth,
+		}, logger)
+		if err != nil {
+			fatal(logger, "failed to load TLS certificate", err)
+		}
+		workers.Go("tls-reloader", func(ctx context.Context) {
+			certs.Run(ctx, cfg.TLS.ReloadInterval)
+		})
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(certs.TLSConfig())))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 	followerpb.RegisterFollowerServiceServer(grpcServer, followHandler)
 	reflection.Register(grpcServer)
 
+	// health je NOT_SERVING dok se baza ne podigne
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(followerpb.FollowerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	a := &app{
+		logger:       logger,
+		cfg:          cfg,
+		grpcServer:   grpcServer,
+		healthServer: healthServer,
+		workers:      workers,
+		repo:         followerRepo,
+		tracing:      shutdownTracing,
+	}
+
 	go func() {
-		logger.Println("Starting gRPC server on", addr)
+		logger.Info("starting gRPC server", "address", addr, "tls", cfg.TLS.Enabled(), "client_auth", cfg.TLS.ClientAuth)
 		if err := grpcServer.Serve(lis); err != nil {
-			logger.Fatal("gRPC server error:", err)
+			fatal(logger, "gRPC server error", err)
 		}
 	}()
 
+	// --- HTTP/JSON gateway (+ gRPC-Web na istom portu) ---
+	if cfg.HTTPAddress != "" {
+		gw := gateway.New(followHandler, interceptors.ChainUnary(unaryInterceptors...), authenticator, cfg.JWT.AdminRole, logger)
+		httpHandler := gw.Handler()
+		if cfg.GRPCWeb.Enabled {
+			httpHandler = gateway.WithGRPCWeb(grpcServer, cfg.GRPCWeb.AllowedOrigins, httpHandler)
+		}
+		a.httpServer = a.serveHTTP("HTTP gateway", cfg.HTTPAddress, httpHandler)
+	}
+
+	// --- Prometheus metrike ---
+	if cfg.MetricsAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", metrics.Handler())
+		a.metricsServer = a.serveHTTP("metrics", cfg.MetricsAddress, mux)
+	}
+
+	// --- Neo4j konekcija (retry sa backoff-om) ---
+	if err := followerRepo.Connect(ctx); err != nil {
+		if ctx.Err() != nil {
+			// signal stigao dok smo čekali bazu
+			a.shutdown()
+			return
+		}
+		logger.Error("failed to connect to Neo4j", "error", err)
+		a.stopNow()
+		os.Exit(1)
+	}
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(followerpb.FollowerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	logger.Info("neo4j connected, service is ready")
+
 	// --- graceful shutdown ---
-	stopCh := make(chan os.Signal, 1)
-	signal.Notify(stopCh, os.Interrupt, syscall.SIGTERM)
-	<-stopCh
+	<-ctx.Done()
+	a.shutdown()
+}
+
+// workerStopTimeout: koliko shutdown čeka pozadinske workere posle otkazivanja
+// (ctx im se otkazuje, pa se upiti u toku prekidaju brzo).
+const workerStopTimeout = 15 * time.Second
+
+// app drži sve što shutdown treba da ugasi.
+type app struct {
+	logger        *slog.Logger
+	cfg           config.Config
+	grpcServer    *grpc.Server
+	httpServer    *http.Server
+	metricsServer *http.Server
+	healthServer  *health.Server
+	workers       *worker.Group
+	repo          *repo.FollowerRepository
+	// tracing flush-uje preostale span-ove
+	tracing func(context.Context) error
+}
 
-	logger.Println("Shutting down gRPC server...")
-	grpcServer.Stop()
+func (a *app) serveHTTP(name, addr string, handler http.Handler) *http.Server {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		a.logger.Info("starting "+name+" server", "address", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal(a.logger, name+" server error", err)
+		}
+	}()
+	return srv
+}
+
+// shutdown: health -> NOT_SERVING, drain in-flight RPC-ova (najviše DrainTimeout),
+// gašenje workera i tek na kraju zatvaranje Neo4j drajvera.
+func (a *app) shutdown() {
+	a.logger.Info("shutting down, draining in-flight requests", "timeout", a.cfg.DrainTimeout)
+	a.healthServer.Shutdown()
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), a.cfg.DrainTimeout)
+	defer cancel()
+
+	// HTTP gateway se drenira paralelno sa gRPC-om, u istom roku
+	httpStopped := make(chan struct{})
+	go func() {
+		defer close(httpStopped)
+		if a.httpServer == nil {
+			return
+		}
+		if err := a.httpServer.Shutdown(drainCtx); err != nil {
+			a.logger.Warn("HTTP gateway did not drain in time", "error", err)
+			a.httpServer.Close()
+		}
+	}()
+
+	stopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-drainCtx.Done():
+		a.logger.Warn("drain timeout exceeded, forcing gRPC server stop")
+		a.grpcServer.Stop()
+		<-stopped
+	}
+	<-httpStopped
+
+	// workeri dobijaju svoj rok: drainCtx je posle prinudnog Stop-a već istekao,
+	// a drajver ne sme da se zatvori dok materializer još šalje upite
+	workersCtx, cancelWorkers := context.WithTimeout(context.Background(), workerStopTimeout)
+	defer cancelWorkers()
+	if err := a.workers.Stop(workersCtx); err != nil {
+		a.logger.Warn("background workers did not stop in time", "error", err)
+	}
+
+	closeCtx, cancelClose := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelClose()
+	if err := a.repo.Close(closeCtx); err != nil {
+		a.logger.Error("failed to close Neo4j driver", "error", err)
+	}
+	if err := a.tracing(closeCtx); err != nil {
+		a.logger.Warn("failed to flush traces", "error", err)
+	}
+	// metrike poslednje, da se drain još vidi u scrape-u
+	if a.metricsServer != nil {
+		a.metricsServer.Close()
+	}
+	a.logger.Info("shutdown complete")
+}
+
+// stopNow gasi sve bez drain-a (neuspešan startup).
+func (a *app) stopNow() {
+	a.grpcServer.Stop()
+	if a.httpServer != nil {
+		a.httpServer.Close()
+	}
+	if a.metricsServer != nil {
+		a.metricsServer.Close()
+	}
+	a.repo.Close(context.Background())
+	a.tracing(context.Background())
+}
+
+// fatal zamenjuje log.Fatal: slog nema Fatal nivo.
+func fatal(logger *slog.Logger, msg string, err error, args ...any) {
+	logger.Error(msg, append([]any{"error", err}, args...)...)
+	os.Exit(1)
+}
+
+// waitReady čeka da repo postane spreman (Connect); false ako je ctx otkazan.
+func waitReady(ctx context.Context, r *repo.FollowerRepository) bool {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for !r.Ready() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
 }
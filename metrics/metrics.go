@@ -0,0 +1,92 @@
+// Package metrics drži Prometheus metrike servisa (RPC, Neo4j upiti, pool).
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	RPCHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+
+	RPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "RPC latency, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "follower_rate_limited_total",
+		Help: "RPCs rejected by the per-user rate limiter, by method and budget (read/write).",
+	}, []string{"grpc_method", "budget"})
+
+	FollowPolicyRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "follower_follow_policy_rejections_total",
+		Help: "Follow requests rejected by the follow policy, by rule (max_followees, daily_quota, churn).",
+	}, []string{"rule"})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "follower_cache_requests_total",
+		Help: "Service cache lookups, by cache (followees, counts, recommendations) and result (hit/miss/bypass).",
+	}, []string{"cache", "result"})
+
+	RecommendationsMaterialized = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "follower_recommendations_materialized_total",
+		Help: "Precomputed recommendation sets written by the background worker, by result (ok/error).",
+	}, []string{"result"})
+
+	QueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "follower_repo_query_duration_seconds",
+		Help:    "Duration of FollowerRepository methods (Neo4j queries).",
+		Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method"})
+
+	QueryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "follower_repo_query_errors_total",
+		Help: "FollowerRepository methods that failed with a database error (domain errors like not found are not counted).",
+	}, []string{"method"})
+
+	Neo4jSessionsInUse = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "neo4j_sessions_in_use",
+		Help: "Neo4j sessions currently open by the repository (each holds a pooled connection while running).",
+	})
+
+	Neo4jPoolMaxSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "neo4j_pool_max_size",
+		Help: "Configured maximum Neo4j connection pool size.",
+	})
+
+	TLSReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "follower_tls_reloads_total",
+		Help: "gRPC server certificate reloads after a file change, by result (ok/error).",
+	}, []string{"result"})
+
+	TLSCertNotAfter = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "follower_tls_cert_not_after_seconds",
+		Help: "Expiry (unix seconds) of the gRPC server certificate currently in use.",
+	})
+
+	Neo4jReady = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "neo4j_ready",
+		Help: "1 when the repository is connected and migrated, 0 otherwise.",
+	})
+)
+
+// ObserveQuery beleži trajanje repo metode; err != nil se broji kao greška baze.
+func ObserveQuery(method string, start time.Time, err error) {
+	QueryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		QueryErrors.WithLabelValues(method).Inc()
+	}
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -13,4 +15,51 @@ type Follow struct {
 type Recommendation struct {
 	UserID string
 	Mutual int64
+	// Via: neki od mojih followee-a koji prate kandidata (objašnjenje preporuke)
+	Via []string
+}
+
+// RecommendationSet su preporuke zajedno sa informacijom odakle su i koliko su sveže.
+type RecommendationSet struct {
+	Items []Recommendation
+	// Precomputed: iz materializovanog skupa; inače računato uživo
+	Precomputed bool
+	ComputedAt  time.Time
+}
+
+// UserPage je jedna strana liste korisnika (followees, followers).
+type UserPage struct {
+	UserIDs []string
+	// TotalCount je ukupan broj u listi, nezavisno od skip/limit
+	TotalCount int64
+	HasMore    bool
+}
+
+type FollowCounts struct {
+	UserID    string
+	Followers int64
+	Followees int64
+}
+
+// FollowActivity su ulazni podaci za follow politiku (repo.GetFollowActivity).
+type FollowActivity struct {
+	Followees int64
+	// FollowsLastDay: nova praćenja u poslednja 24h (klizni prozor)
+	FollowsLastDay int64
+	// Following i RecentChanges se odnose na konkretnog target-a
+	Following     bool
+	RecentChanges int64
+}
+
+// FollowPolicy je stanje limita za korisnika; Remaining* je -1 kad je pravilo isključeno.
+type FollowPolicy struct {
+	UserID                string
+	MaxFollowees          int64
+	Followees             int64
+	RemainingFollowees    int64
+	DailyFollowQuota      int64
+	FollowsLastDay        int64
+	RemainingDailyFollows int64
+	ChurnWindow           time.Duration
+	ChurnMaxChanges       int64
 }
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Vrste zapisa u snapshot fajlu
+const (
+	SnapshotUser    = "user"
+	SnapshotFollows = "follows"
+	SnapshotBlocks  = "blocks"
+)
+
+// SnapshotRecord je jedan red snapshot-a: User čvor, FOLLOWS ili BLOCKS veza.
+type SnapshotRecord struct {
+	Type       string     `json:"type"`
+	ID         string     `json:"id,omitempty"`
+	FollowerID string     `json:"followerId,omitempty"`
+	FolloweeID string     `json:"followeeId,omitempty"`
+	BlockerID  string     `json:"blockerId,omitempty"`
+	BlockedID  string     `json:"blockedId,omitempty"`
+	Since      *time.Time `json:"since,omitempty"`
+}
+
+type FollowEdge struct {
+	FollowerID string
+	FolloweeID string
+	Since      *time.Time
+}
+
+type BlockEdge struct {
+	BlockerID string
+	BlockedID string
+	Since     *time.Time
+}
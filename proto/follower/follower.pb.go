@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +23,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type RecommendationSource int32
+
+const (
+	RecommendationSource_RECOMMENDATION_SOURCE_UNSPECIFIED RecommendationSource = 0
+	RecommendationSource_RECOMMENDATION_SOURCE_LIVE        RecommendationSource = 1 // izračunato pri zahtevu
+	RecommendationSource_RECOMMENDATION_SOURCE_PRECOMPUTED RecommendationSource = 2 // iz skupa koji pozadinski worker unapred računa
+)
+
+// Enum value maps for RecommendationSource.
+var (
+	RecommendationSource_name = map[int32]string{
+		0: "RECOMMENDATION_SOURCE_UNSPECIFIED",
+		1: "RECOMMENDATION_SOURCE_LIVE",
+		2: "RECOMMENDATION_SOURCE_PRECOMPUTED",
+	}
+	RecommendationSource_value = map[string]int32{
+		"RECOMMENDATION_SOURCE_UNSPECIFIED": 0,
+		"RECOMMENDATION_SOURCE_LIVE":        1,
+		"RECOMMENDATION_SOURCE_PRECOMPUTED": 2,
+	}
+)
+
+func (x RecommendationSource) Enum() *RecommendationSource {
+	p := new(RecommendationSource)
+	*p = x
+	return p
+}
+
+func (x RecommendationSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecommendationSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_follower_follower_proto_enumTypes[0].Descriptor()
+}
+
+func (RecommendationSource) Type() protoreflect.EnumType {
+	return &file_proto_follower_follower_proto_enumTypes[0]
+}
+
+func (x RecommendationSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecommendationSource.Descriptor instead.
+func (RecommendationSource) EnumDescriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{0}
+}
+
 type PingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -262,6 +312,7 @@ type Recommendation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Mutual        int64                  `protobuf:"varint,2,opt,name=mutual,proto3" json:"mutual,omitempty"` // koliko tvojih prati kandidata
+	Via           []string               `protobuf:"bytes,3,rep,name=via,proto3" json:"via,omitempty"`        // neki od tvojih koji prate kandidata (objašnjenje)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -310,9 +361,18 @@ func (x *Recommendation) GetMutual() int64 {
 	return 0
 }
 
+func (x *Recommendation) GetVia() []string {
+	if x != nil {
+		return x.Via
+	}
+	return nil
+}
+
 type GetRecommendationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*Recommendation      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Source        RecommendationSource   `protobuf:"varint,2,opt,name=source,proto3,enum=follower.RecommendationSource" json:"source,omitempty"`
+	ComputedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"` // kada je skup izračunat (svežina)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -354,11 +414,25 @@ func (x *GetRecommendationsResponse) GetItems() []*Recommendation {
 	return nil
 }
 
+func (x *GetRecommendationsResponse) GetSource() RecommendationSource {
+	if x != nil {
+		return x.Source
+	}
+	return RecommendationSource_RECOMMENDATION_SOURCE_UNSPECIFIED
+}
+
+func (x *GetRecommendationsResponse) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
 type GetFolloweesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // koga pratimo (iz JWT-a ili eksplicitno)
 	Skip          int32                  `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`                  // opcionalna paginacija
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                // default 20
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                // default 20, najviše pagination.maxPageSize
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -416,7 +490,9 @@ func (x *GetFolloweesRequest) GetLimit() int32 {
 
 type GetFolloweesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // lista ID-jeva koje user prati
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`           // lista ID-jeva koje user prati
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // ukupno, nezavisno od skip/limit
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`          // postoji sledeća strana
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -458,11 +534,506 @@ func (x *GetFolloweesResponse) GetUserIds() []string {
 	return nil
 }
 
+func (x *GetFolloweesResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetFolloweesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetFollowersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // čije pratioce tražimo
+	Skip          int32                  `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`                  // opcionalna paginacija
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                // default 20, najviše pagination.maxPageSize
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowersRequest) Reset() {
+	*x = GetFollowersRequest{}
+	mi := &file_proto_follower_follower_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowersRequest) ProtoMessage() {}
+
+func (x *GetFollowersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follower_follower_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowersRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetFollowersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetFollowersRequest) GetSkip() int32 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *GetFollowersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetFollowersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // lista ID-jeva koji prate usera
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowersResponse) Reset() {
+	*x = GetFollowersResponse{}
+	mi := &file_proto_follower_follower_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowersResponse) ProtoMessage() {}
+
+func (x *GetFollowersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follower_follower_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowersResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetFollowersResponse) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *GetFollowersResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetFollowersResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetFollowCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowCountsRequest) Reset() {
+	*x = GetFollowCountsRequest{}
+	mi := &file_proto_follower_follower_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowCountsRequest) ProtoMessage() {}
+
+func (x *GetFollowCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follower_follower_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowCountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetFollowCountsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetFollowCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Followers     int64                  `protobuf:"varint,2,opt,name=followers,proto3" json:"followers,omitempty"` // koliko ljudi prati usera
+	Followees     int64                  `protobuf:"varint,3,opt,name=followees,proto3" json:"followees,omitempty"` // koliko ljudi user prati
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowCountsResponse) Reset() {
+	*x = GetFollowCountsResponse{}
+	mi := &file_proto_follower_follower_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowCountsResponse) ProtoMessage() {}
+
+func (x *GetFollowCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follower_follower_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowCountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetFollowCountsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetFollowCountsResponse) GetFollowers() int64 {
+	if x != nil {
+		return x.Followers
+	}
+	return 0
+}
+
+func (x *GetFollowCountsResponse) GetFollowees() int64 {
+	if x != nil {
+		return x.Followees
+	}
+	return 0
+}
+
+type BlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockerId     string                 `protobuf:"bytes,1,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"` // ko blokira
+	BlockedId     string                 `protobuf:"bytes,2,opt,name=blocked_id,json=blockedId,proto3" json:"blocked_id,omitempty"` // koga blokira
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockRequest) Reset() {
+	*x = BlockRequest{}
+	mi := &file_proto_follower_follower_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRequest) ProtoMessage() {}
+
+func (x *BlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follower_follower_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRequest.ProtoReflect.Descriptor instead.
+func (*BlockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BlockRequest) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+func (x *BlockRequest) GetBlockedId() string {
+	if x != nil {
+		return x.BlockedId
+	}
+	return ""
+}
+
+type UnblockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockerId     string                 `protobuf:"bytes,1,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"`
+	BlockedId     string                 `protobuf:"bytes,2,opt,name=blocked_id,json=blockedId,proto3" json:"blocked_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockRequest) Reset() {
+	*x = UnblockRequest{}
+	mi := &file_proto_follower_follower_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockRequest) ProtoMessage() {}
+
+func (x *UnblockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follower_follower_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockRequest.ProtoReflect.Descriptor instead.
+func (*UnblockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UnblockRequest) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+func (x *UnblockRequest) GetBlockedId() string {
+	if x != nil {
+		return x.BlockedId
+	}
+	return ""
+}
+
+type GetFollowPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowPolicyRequest) Reset() {
+	*x = GetFollowPolicyRequest{}
+	mi := &file_proto_follower_follower_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowPolicyRequest) ProtoMessage() {}
+
+func (x *GetFollowPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follower_follower_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetFollowPolicyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Limiti praćenja i koliko je korisniku ostalo; remaining_* je -1 kad je pravilo isključeno.
+type GetFollowPolicyResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MaxFollowees          int64                  `protobuf:"varint,2,opt,name=max_followees,json=maxFollowees,proto3" json:"max_followees,omitempty"`
+	Followees             int64                  `protobuf:"varint,3,opt,name=followees,proto3" json:"followees,omitempty"`
+	RemainingFollowees    int64                  `protobuf:"varint,4,opt,name=remaining_followees,json=remainingFollowees,proto3" json:"remaining_followees,omitempty"`
+	DailyFollowQuota      int64                  `protobuf:"varint,5,opt,name=daily_follow_quota,json=dailyFollowQuota,proto3" json:"daily_follow_quota,omitempty"` // klizni prozor od 24h
+	FollowsLastDay        int64                  `protobuf:"varint,6,opt,name=follows_last_day,json=followsLastDay,proto3" json:"follows_last_day,omitempty"`
+	RemainingDailyFollows int64                  `protobuf:"varint,7,opt,name=remaining_daily_follows,json=remainingDailyFollows,proto3" json:"remaining_daily_follows,omitempty"`
+	ChurnWindowSeconds    int64                  `protobuf:"varint,8,opt,name=churn_window_seconds,json=churnWindowSeconds,proto3" json:"churn_window_seconds,omitempty"`
+	ChurnMaxChanges       int64                  `protobuf:"varint,9,opt,name=churn_max_changes,json=churnMaxChanges,proto3" json:"churn_max_changes,omitempty"` // follow+unfollow istog korisnika u prozoru
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetFollowPolicyResponse) Reset() {
+	*x = GetFollowPolicyResponse{}
+	mi := &file_proto_follower_follower_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowPolicyResponse) ProtoMessage() {}
+
+func (x *GetFollowPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follower_follower_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_follower_follower_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetFollowPolicyResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetFollowPolicyResponse) GetMaxFollowees() int64 {
+	if x != nil {
+		return x.MaxFollowees
+	}
+	return 0
+}
+
+func (x *GetFollowPolicyResponse) GetFollowees() int64 {
+	if x != nil {
+		return x.Followees
+	}
+	return 0
+}
+
+func (x *GetFollowPolicyResponse) GetRemainingFollowees() int64 {
+	if x != nil {
+		return x.RemainingFollowees
+	}
+	return 0
+}
+
+func (x *GetFollowPolicyResponse) GetDailyFollowQuota() int64 {
+	if x != nil {
+		return x.DailyFollowQuota
+	}
+	return 0
+}
+
+func (x *GetFollowPolicyResponse) GetFollowsLastDay() int64 {
+	if x != nil {
+		return x.FollowsLastDay
+	}
+	return 0
+}
+
+func (x *GetFollowPolicyResponse) GetRemainingDailyFollows() int64 {
+	if x != nil {
+		return x.RemainingDailyFollows
+	}
+	return 0
+}
+
+func (x *GetFollowPolicyResponse) GetChurnWindowSeconds() int64 {
+	if x != nil {
+		return x.ChurnWindowSeconds
+	}
+	return 0
+}
+
+func (x *GetFollowPolicyResponse) GetChurnMaxChanges() int64 {
+	if x != nil {
+		return x.ChurnMaxChanges
+	}
+	return 0
+}
+
 var File_proto_follower_follower_proto protoreflect.FileDescriptor
 
 const file_proto_follower_follower_proto_rawDesc = "" +
 	"\n" +
-	"\x1dproto/follower/follower.proto\x12\bfollower\x1a\x1bgoogle/protobuf/empty.proto\"\r\n" +
+	"\x1dproto/follower/follower.proto\x12\bfollower\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\r\n" +
 	"\vPingRequest\"(\n" +
 	"\fPingResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"Q\n" +
@@ -478,24 +1049,77 @@ const file_proto_follower_follower_proto_rawDesc = "" +
 	"followerId\"J\n" +
 	"\x19GetRecommendationsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"A\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"S\n" +
 	"\x0eRecommendation\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06mutual\x18\x02 \x01(\x03R\x06mutual\"L\n" +
+	"\x06mutual\x18\x02 \x01(\x03R\x06mutual\x12\x10\n" +
+	"\x03via\x18\x03 \x03(\tR\x03via\"\xc1\x01\n" +
 	"\x1aGetRecommendationsResponse\x12.\n" +
-	"\x05items\x18\x01 \x03(\v2\x18.follower.RecommendationR\x05items\"X\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.follower.RecommendationR\x05items\x126\n" +
+	"\x06source\x18\x02 \x01(\x0e2\x1e.follower.RecommendationSourceR\x06source\x12;\n" +
+	"\vcomputed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAt\"X\n" +
 	"\x13GetFolloweesRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04skip\x18\x02 \x01(\x05R\x04skip\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\"1\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"m\n" +
 	"\x14GetFolloweesResponse\x12\x19\n" +
-	"\buser_ids\x18\x01 \x03(\tR\auserIds2\xf2\x02\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"X\n" +
+	"\x13GetFollowersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04skip\x18\x02 \x01(\x05R\x04skip\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"m\n" +
+	"\x14GetFollowersResponse\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"1\n" +
+	"\x16GetFollowCountsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"n\n" +
+	"\x17GetFollowCountsResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\tfollowers\x18\x02 \x01(\x03R\tfollowers\x12\x1c\n" +
+	"\tfollowees\x18\x03 \x01(\x03R\tfollowees\"L\n" +
+	"\fBlockRequest\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x01 \x01(\tR\tblockerId\x12\x1d\n" +
+	"\n" +
+	"blocked_id\x18\x02 \x01(\tR\tblockedId\"N\n" +
+	"\x0eUnblockRequest\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x01 \x01(\tR\tblockerId\x12\x1d\n" +
+	"\n" +
+	"blocked_id\x18\x02 \x01(\tR\tblockedId\"1\n" +
+	"\x16GetFollowPolicyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x94\x03\n" +
+	"\x17GetFollowPolicyResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rmax_followees\x18\x02 \x01(\x03R\fmaxFollowees\x12\x1c\n" +
+	"\tfollowees\x18\x03 \x01(\x03R\tfollowees\x12/\n" +
+	"\x13remaining_followees\x18\x04 \x01(\x03R\x12remainingFollowees\x12,\n" +
+	"\x12daily_follow_quota\x18\x05 \x01(\x03R\x10dailyFollowQuota\x12(\n" +
+	"\x10follows_last_day\x18\x06 \x01(\x03R\x0efollowsLastDay\x126\n" +
+	"\x17remaining_daily_follows\x18\a \x01(\x03R\x15remainingDailyFollows\x120\n" +
+	"\x14churn_window_seconds\x18\b \x01(\x03R\x12churnWindowSeconds\x12*\n" +
+	"\x11churn_max_changes\x18\t \x01(\x03R\x0fchurnMaxChanges*\x84\x01\n" +
+	"\x14RecommendationSource\x12%\n" +
+	"!RECOMMENDATION_SOURCE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aRECOMMENDATION_SOURCE_LIVE\x10\x01\x12%\n" +
+	"!RECOMMENDATION_SOURCE_PRECOMPUTED\x10\x022\xe7\x05\n" +
 	"\x0fFollowerService\x125\n" +
 	"\x04Ping\x12\x15.follower.PingRequest\x1a\x16.follower.PingResponse\x129\n" +
 	"\x06Follow\x12\x17.follower.FollowRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
 	"\bUnfollow\x12\x19.follower.UnfollowRequest\x1a\x16.google.protobuf.Empty\x12_\n" +
 	"\x12GetRecommendations\x12#.follower.GetRecommendationsRequest\x1a$.follower.GetRecommendationsResponse\x12M\n" +
-	"\fGetFollowees\x12\x1d.follower.GetFolloweesRequest\x1a\x1e.follower.GetFolloweesResponseB,Z*database-example/proto/follower;followerpbb\x06proto3"
+	"\fGetFollowees\x12\x1d.follower.GetFolloweesRequest\x1a\x1e.follower.GetFolloweesResponse\x12M\n" +
+	"\fGetFollowers\x12\x1d.follower.GetFollowersRequest\x1a\x1e.follower.GetFollowersResponse\x12V\n" +
+	"\x0fGetFollowCounts\x12 .follower.GetFollowCountsRequest\x1a!.follower.GetFollowCountsResponse\x127\n" +
+	"\x05Block\x12\x16.follower.BlockRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\aUnblock\x12\x18.follower.UnblockRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x0fGetFollowPolicy\x12 .follower.GetFollowPolicyRequest\x1a!.follower.GetFollowPolicyResponseB,Z*database-example/proto/follower;followerpbb\x06proto3"
 
 var (
 	file_proto_follower_follower_proto_rawDescOnce sync.Once
@@ -509,36 +1133,59 @@ func file_proto_follower_follower_proto_rawDescGZIP() []byte {
 	return file_proto_follower_follower_proto_rawDescData
 }
 
-var file_proto_follower_follower_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_follower_follower_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_follower_follower_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_proto_follower_follower_proto_goTypes = []any{
-	(*PingRequest)(nil),                // 0: follower.PingRequest
-	(*PingResponse)(nil),               // 1: follower.PingResponse
-	(*FollowRequest)(nil),              // 2: follower.FollowRequest
-	(*UnfollowRequest)(nil),            // 3: follower.UnfollowRequest
-	(*GetRecommendationsRequest)(nil),  // 4: follower.GetRecommendationsRequest
-	(*Recommendation)(nil),             // 5: follower.Recommendation
-	(*GetRecommendationsResponse)(nil), // 6: follower.GetRecommendationsResponse
-	(*GetFolloweesRequest)(nil),        // 7: follower.GetFolloweesRequest
-	(*GetFolloweesResponse)(nil),       // 8: follower.GetFolloweesResponse
-	(*emptypb.Empty)(nil),              // 9: google.protobuf.Empty
+	(RecommendationSource)(0),          // 0: follower.RecommendationSource
+	(*PingRequest)(nil),                // 1: follower.PingRequest
+	(*PingResponse)(nil),               // 2: follower.PingResponse
+	(*FollowRequest)(nil),              // 3: follower.FollowRequest
+	(*UnfollowRequest)(nil),            // 4: follower.UnfollowRequest
+	(*GetRecommendationsRequest)(nil),  // 5: follower.GetRecommendationsRequest
+	(*Recommendation)(nil),             // 6: follower.Recommendation
+	(*GetRecommendationsResponse)(nil), // 7: follower.GetRecommendationsResponse
+	(*GetFolloweesRequest)(nil),        // 8: follower.GetFolloweesRequest
+	(*GetFolloweesResponse)(nil),       // 9: follower.GetFolloweesResponse
+	(*GetFollowersRequest)(nil),        // 10: follower.GetFollowersRequest
+	(*GetFollowersResponse)(nil),       // 11: follower.GetFollowersResponse
+	(*GetFollowCountsRequest)(nil),     // 12: follower.GetFollowCountsRequest
+	(*GetFollowCountsResponse)(nil),    // 13: follower.GetFollowCountsResponse
+	(*BlockRequest)(nil),               // 14: follower.BlockRequest
+	(*UnblockRequest)(nil),             // 15: follower.UnblockRequest
+	(*GetFollowPolicyRequest)(nil),     // 16: follower.GetFollowPolicyRequest
+	(*GetFollowPolicyResponse)(nil),    // 17: follower.GetFollowPolicyResponse
+	(*timestamppb.Timestamp)(nil),      // 18: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),              // 19: google.protobuf.Empty
 }
 var file_proto_follower_follower_proto_depIdxs = []int32{
-	5, // 0: follower.GetRecommendationsResponse.items:type_name -> follower.Recommendation
-	0, // 1: follower.FollowerService.Ping:input_type -> follower.PingRequest
-	2, // 2: follower.FollowerService.Follow:input_type -> follower.FollowRequest
-	3, // 3: follower.FollowerService.Unfollow:input_type -> follower.UnfollowRequest
-	4, // 4: follower.FollowerService.GetRecommendations:input_type -> follower.GetRecommendationsRequest
-	7, // 5: follower.FollowerService.GetFollowees:input_type -> follower.GetFolloweesRequest
-	1, // 6: follower.FollowerService.Ping:output_type -> follower.PingResponse
-	9, // 7: follower.FollowerService.Follow:output_type -> google.protobuf.Empty
-	9, // 8: follower.FollowerService.Unfollow:output_type -> google.protobuf.Empty
-	6, // 9: follower.FollowerService.GetRecommendations:output_type -> follower.GetRecommendationsResponse
-	8, // 10: follower.FollowerService.GetFollowees:output_type -> follower.GetFolloweesResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	6,  // 0: follower.GetRecommendationsResponse.items:type_name -> follower.Recommendation
+	0,  // 1: follower.GetRecommendationsResponse.source:type_name -> follower.RecommendationSource
+	18, // 2: follower.GetRecommendationsResponse.computed_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: follower.FollowerService.Ping:input_type -> follower.PingRequest
+	3,  // 4: follower.FollowerService.Follow:input_type -> follower.FollowRequest
+	4,  // 5: follower.FollowerService.Unfollow:input_type -> follower.UnfollowRequest
+	5,  // 6: follower.FollowerService.GetRecommendations:input_type -> follower.GetRecommendationsRequest
+	8,  // 7: follower.FollowerService.GetFollowees:input_type -> follower.GetFolloweesRequest
+	10, // 8: follower.FollowerService.GetFollowers:input_type -> follower.GetFollowersRequest
+	12, // 9: follower.FollowerService.GetFollowCounts:input_type -> follower.GetFollowCountsRequest
+	14, // 10: follower.FollowerService.Block:input_type -> follower.BlockRequest
+	15, // 11: follower.FollowerService.Unblock:input_type -> follower.UnblockRequest
+	16, // 12: follower.FollowerService.GetFollowPolicy:input_type -> follower.GetFollowPolicyRequest
+	2,  // 13: follower.FollowerService.Ping:output_type -> follower.PingResponse
+	19, // 14: follower.FollowerService.Follow:output_type -> google.protobuf.Empty
+	19, // 15: follower.FollowerService.Unfollow:output_type -> google.protobuf.Empty
+	7,  // 16: follower.FollowerService.GetRecommendations:output_type -> follower.GetRecommendationsResponse
+	9,  // 17: follower.FollowerService.GetFollowees:output_type -> follower.GetFolloweesResponse
+	11, // 18: follower.FollowerService.GetFollowers:output_type -> follower.GetFollowersResponse
+	13, // 19: follower.FollowerService.GetFollowCounts:output_type -> follower.GetFollowCountsResponse
+	19, // 20: follower.FollowerService.Block:output_type -> google.protobuf.Empty
+	19, // 21: follower.FollowerService.Unblock:output_type -> google.protobuf.Empty
+	17, // 22: follower.FollowerService.GetFollowPolicy:output_type -> follower.GetFollowPolicyResponse
+	13, // [13:23] is the sub-list for method output_type
+	3,  // [3:13] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_follower_follower_proto_init() }
@@ -551,13 +1198,14 @@ func file_proto_follower_follower_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_follower_follower_proto_rawDesc), len(file_proto_follower_follower_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      1,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_follower_follower_proto_goTypes,
 		DependencyIndexes: file_proto_follower_follower_proto_depIdxs,
+		EnumInfos:         file_proto_follower_follower_proto_enumTypes,
 		MessageInfos:      file_proto_follower_follower_proto_msgTypes,
 	}.Build()
 	File_proto_follower_follower_proto = out.File
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter je token bucket po ključu, u memoriji jedne replike.
+type MemoryLimiter struct {
+	limit Limit
+	rate  float64 // tokena po sekundi
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+var _ Limiter = (*MemoryLimiter)(nil)
+
+func NewMemoryLimiter(limit Limit) *MemoryLimiter {
+	return &MemoryLimiter{
+		limit:   limit,
+		rate:    limit.RequestsPerMinute / 60,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.refill(now, l.rate, l.limit.Burst)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	missing := 1 - b.tokens
+	return false, time.Duration(missing / l.rate * float64(time.Second)), nil
+}
+
+func (b *bucket) refill(now time.Time, rate float64, burst int) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(float64(burst), b.tokens+elapsed*rate)
+		b.last = now
+	}
+}
+
+// Sweep briše pune bucket-e (ključeve koji su mirovali dovoljno dugo), da mapa
+// ne bi rasla sa svakim IP-jem koji je ikad poslao zahtev.
+func (l *MemoryLimiter) Sweep() {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key, b := range l.buckets {
+		b.refill(now, l.rate, l.limit.Burst)
+		if b.tokens >= float64(l.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RunSweeper poziva Sweep na svaki interval dok se ctx ne otkaže (worker.Group).
+func (l *MemoryLimiter) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.Sweep()
+		}
+	}
+}
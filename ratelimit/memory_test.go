@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock je sat koji test pomera ručno.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(limit Limit) (*MemoryLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewMemoryLimiter(limit)
+	l.now = clock.now
+	return l, clock
+}
+
+func allow(t *testing.T, l *MemoryLimiter, key string) (bool, time.Duration) {
+	t.Helper()
+	ok, retryAfter, err := l.Allow(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ok, retryAfter
+}
+
+func TestMemoryLimiterBurstAndRefill(t *testing.T) {
+	// 60/min = jedan token u sekundi
+	l, clock := newTestLimiter(Limit{RequestsPerMinute: 60, Burst: 3})
+
+	for i := range 3 {
+		if ok, _ := allow(t, l, "user:a"); !ok {
+			t.Fatalf("request %d within burst denied", i+1)
+		}
+	}
+	ok, retryAfter := allow(t, l, "user:a")
+	if ok || retryAfter != time.Second {
+		t.Fatalf("over burst: allowed=%v retryAfter=%v, want denied after 1s", ok, retryAfter)
+	}
+
+	// pola tokena: i dalje odbijeno, ali kraće čekanje
+	clock.advance(500 * time.Millisecond)
+	if ok, retryAfter := allow(t, l, "user:a"); ok || retryAfter != 500*time.Millisecond {
+		t.Fatalf("after 0.5s: allowed=%v retryAfter=%v, want denied after 0.5s", ok, retryAfter)
+	}
+	clock.advance(500 * time.Millisecond)
+	if ok, _ := allow(t, l, "user:a"); !ok {
+		t.Fatal("after refill of one token: denied")
+	}
+
+	// dopuna ne prelazi Burst
+	clock.advance(time.Hour)
+	for i := range 3 {
+		if ok, _ := allow(t, l, "user:a"); !ok {
+			t.Fatalf("after long idle, request %d denied", i+1)
+		}
+	}
+	if ok, _ := allow(t, l, "user:a"); ok {
+		t.Fatal("bucket refilled above Burst")
+	}
+}
+
+func TestMemoryLimiterKeysAreIndependent(t *testing.T) {
+	l, _ := newTestLimiter(Limit{RequestsPerMinute: 1, Burst: 1})
+	if ok, _ := allow(t, l, "user:a"); !ok {
+		t.Fatal("user:a denied")
+	}
+	if ok, _ := allow(t, l, "user:a"); ok {
+		t.Fatal("user:a allowed twice")
+	}
+	if ok, _ := allow(t, l, "user:b"); !ok {
+		t.Fatal("user:b denied because of user:a")
+	}
+}
+
+func TestMemoryLimiterSweep(t *testing.T) {
+	l, clock := newTestLimiter(Limit{RequestsPerMinute: 60, Burst: 2})
+	allow(t, l, "idle")
+	allow(t, l, "busy")
+	allow(t, l, "busy")
+
+	// posle 1s "idle" je ponovo pun, "busy" ima tek jedan od dva tokena
+	clock.advance(time.Second)
+	l.Sweep()
+	if _, ok := l.buckets["idle"]; ok {
+		t.Error("full bucket not evicted")
+	}
+	if _, ok := l.buckets["busy"]; !ok {
+		t.Fatal("partially used bucket evicted")
+	}
+
+	// izbacivanje ne sme da vrati potrošene tokene: busy i dalje ima samo jedan
+	if ok, _ := allow(t, l, "busy"); !ok {
+		t.Fatal("busy: refilled token denied")
+	}
+	if ok, _ := allow(t, l, "busy"); ok {
+		t.Fatal("busy: got more tokens than refilled")
+	}
+
+	clock.advance(time.Minute)
+	l.Sweep()
+	if len(l.buckets) != 0 {
+		t.Errorf("buckets after idle minute = %d, want 0", len(l.buckets))
+	}
+}
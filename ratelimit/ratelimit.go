@@ -0,0 +1,21 @@
+// Package ratelimit ograničava broj zahteva po ključu (korisnik ili IP).
+// Limiter je interfejs da bi se za više replika mogao ubaciti deljeni store
+// (npr. Redis) umesto in-memory token bucket-a.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit: RequestsPerMinute tokena se dopunjava u minuti, najviše Burst odjednom.
+type Limit struct {
+	RequestsPerMinute float64
+	Burst             int
+}
+
+type Limiter interface {
+	// Allow troši jedan token za key. Kada nije dozvoljeno, retryAfter kaže
+	// koliko treba sačekati do sledećeg tokena.
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
@@ -0,0 +1,154 @@
+// Package recommendations unapred računa (materializuje) friends-of-friends
+// preporuke za aktivne korisnike, van request path-a, i čuva ih u grafu.
+package recommendations
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"database-example/config"
+	"database-example/metrics"
+	"database-example/model"
+)
+
+// Store je deo FollowerRepository-ja koji materializer koristi.
+type Store interface {
+	GetRecommendations(ctx context.Context, userID string, limit int) ([]model.Recommendation, error)
+	SaveRecommendations(ctx context.Context, userID string, recs []model.Recommendation, computedAt time.Time) error
+	StaleRecommendationUsers(ctx context.Context, activeSince, staleBefore time.Time, limit int) ([]string, error)
+}
+
+const (
+	// maxPending ograničava red; višak pokupi sledeći periodični prolaz
+	maxPending = 10000
+	// defaultDebounce skuplja više follow promena istog korisnika u jedno računanje
+	defaultDebounce = 2 * time.Second
+)
+
+type Materializer struct {
+	store  Store
+	cfg    config.RecommendationsConfig
+	logger *slog.Logger
+	// OnRefresh se poziva posle upisa novog skupa (npr. invalidacija keša u servisu)
+	OnRefresh func(ctx context.Context, userID string)
+
+	debounce time.Duration
+	wake     chan struct{}
+	mu       sync.Mutex
+	pending  map[string]struct{}
+}
+
+func New(store Store, cfg config.RecommendationsConfig, logger *slog.Logger) *Materializer {
+	return &Materializer{
+		store:    store,
+		cfg:      cfg,
+		logger:   logger.With("component", "recommendations"),
+		debounce: defaultDebounce,
+		wake:     make(chan struct{}, 1),
+		pending:  make(map[string]struct{}),
+	}
+}
+
+// Enqueue zakazuje ponovno računanje za korisnike; ne blokira, poziva se iz servisa
+// posle Follow/Unfollow/Block i kada se preporuke služe uživo.
+func (m *Materializer) Enqueue(userIDs ...string) {
+	m.mu.Lock()
+	for _, id := range userIDs {
+		if len(m.pending) >= maxPending {
+			break
+		}
+		m.pending[id] = struct{}{}
+	}
+	m.mu.Unlock()
+
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run obrađuje red i na svaki RefreshInterval osvežava zastarele skupove aktivnih
+// korisnika; pokreće se kroz worker.Group.
+func (m *Materializer) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	m.refreshStale(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.wake:
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(m.debounce):
+			}
+			m.drainPending(ctx)
+		case <-ticker.C:
+			m.refreshStale(ctx)
+		}
+	}
+}
+
+func (m *Materializer) drainPending(ctx context.Context) {
+	m.mu.Lock()
+	ids := make([]string, 0, len(m.pending))
+	for id := range m.pending {
+		ids = append(ids, id)
+	}
+	clear(m.pending)
+	m.mu.Unlock()
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		m.refresh(ctx, id)
+	}
+}
+
+func (m *Materializer) refreshStale(ctx context.Context) {
+	now := time.Now()
+	ids, err := m.store.StaleRecommendationUsers(ctx, now.Add(-m.cfg.ActiveWindow), now.Add(-m.cfg.RefreshInterval), m.cfg.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.logger.Warn("failed to list users with stale recommendations", "error", err)
+		}
+		return
+	}
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		m.refresh(ctx, id)
+	}
+	if len(ids) > 0 {
+		m.logger.Info("refreshed stale recommendations", "users", len(ids))
+	}
+}
+
+// refresh računa TopN preporuka uživo (sa vremenskim limitom) i upisuje ih.
+func (m *Materializer) refresh(ctx context.Context, userID string) {
+	computeCtx, cancel := context.WithTimeout(ctx, m.cfg.ComputeTimeout)
+	defer cancel()
+
+	computedAt := time.Now().UTC()
+	recs, err := m.store.GetRecommendations(computeCtx, userID, m.cfg.TopN)
+	if err == nil {
+		err = m.store.SaveRecommendations(computeCtx, userID, recs, computedAt)
+	}
+	if err != nil {
+		metrics.RecommendationsMaterialized.WithLabelValues("error").Inc()
+		if ctx.Err() == nil {
+			m.logger.Warn("failed to materialize recommendations", "user_id", userID, "error", err)
+		}
+		return
+	}
+	metrics.RecommendationsMaterialized.WithLabelValues("ok").Inc()
+	if m.OnRefresh != nil {
+		m.OnRefresh(ctx, userID)
+	}
+}
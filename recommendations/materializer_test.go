@@ -0,0 +1,213 @@
+package recommendations
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"database-example/config"
+	"database-example/model"
+)
+
+type fakeStore struct {
+	mu       sync.Mutex
+	computed map[string]int
+	saved    map[string][]model.Recommendation
+	fail     map[string]bool
+	stale    []string
+
+	// staleArgs: argumenti poslednjeg StaleRecommendationUsers poziva
+	activeSince, staleBefore time.Time
+	staleLimit               int
+
+	savedCh chan string
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		computed: map[string]int{},
+		saved:    map[string][]model.Recommendation{},
+		fail:     map[string]bool{},
+		savedCh:  make(chan string, 100),
+	}
+}
+
+func (s *fakeStore) GetRecommendations(ctx context.Context, userID string, limit int) ([]model.Recommendation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.computed[userID]++
+	if s.fail[userID] {
+		return nil, errors.New("neo4j unavailable")
+	}
+	return []model.Recommendation{{UserID: "fof-of-" + userID, Mutual: int64(limit)}}, nil
+}
+
+func (s *fakeStore) SaveRecommendations(ctx context.Context, userID string, recs []model.Recommendation, computedAt time.Time) error {
+	s.mu.Lock()
+	s.saved[userID] = recs
+	s.mu.Unlock()
+	select {
+	case s.savedCh <- userID:
+	default:
+	}
+	return nil
+}
+
+func (s *fakeStore) StaleRecommendationUsers(ctx context.Context, activeSince, staleBefore time.Time, limit int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.activeSince, s.staleBefore, s.staleLimit = activeSince, staleBefore, limit
+	return s.stale, nil
+}
+
+func (s *fakeStore) computedCount(id string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.computed[id]
+}
+
+func testConfig() config.RecommendationsConfig {
+	return config.RecommendationsConfig{
+		Precompute:      true,
+		TopN:            5,
+		RefreshInterval: time.Hour,
+		MaxAge:          time.Hour,
+		ActiveWindow:    24 * time.Hour,
+		BatchSize:       10,
+		ComputeTimeout:  time.Second,
+	}
+}
+
+func newTestMaterializer(store Store) *Materializer {
+	m := New(store, testConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	m.debounce = 50 * time.Millisecond
+	return m
+}
+
+func waitSaved(t *testing.T, s *fakeStore, want ...string) {
+	t.Helper()
+	left := map[string]bool{}
+	for _, id := range want {
+		left[id] = true
+	}
+	deadline := time.After(2 * time.Second)
+	for len(left) > 0 {
+		select {
+		case id := <-s.savedCh:
+			delete(left, id)
+		case <-deadline:
+			t.Fatalf("timed out waiting for saves of %v", left)
+		}
+	}
+}
+
+// Više Enqueue poziva u debounce prozoru daje jedno računanje po korisniku.
+func TestMaterializerDebounce(t *testing.T) {
+	store := newFakeStore()
+	m := newTestMaterializer(store)
+	refreshed := make(chan string, 10)
+	m.OnRefresh = func(ctx context.Context, userID string) { refreshed <- userID }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Run(ctx)
+
+	m.Enqueue("alice")
+	m.Enqueue("alice", "bob")
+	m.Enqueue("alice")
+	waitSaved(t, store, "alice", "bob")
+
+	// posle obrade ne sme da stigne još jedno računanje za isti talas
+	time.Sleep(3 * m.debounce)
+	if n := store.computedCount("alice"); n != 1 {
+		t.Errorf("alice computed %d times, want 1", n)
+	}
+	if n := store.computedCount("bob"); n != 1 {
+		t.Errorf("bob computed %d times, want 1", n)
+	}
+	if got := store.saved["alice"]; len(got) != 1 || got[0].Mutual != 5 {
+		t.Errorf("saved = %+v, want TopN (5) recommendations", got)
+	}
+	if len(refreshed) != 2 {
+		t.Errorf("OnRefresh called %d times, want 2", len(refreshed))
+	}
+
+	// nova promena posle obrade se ponovo računa
+	m.Enqueue("alice")
+	waitSaved(t, store, "alice")
+	if n := store.computedCount("alice"); n != 2 {
+		t.Errorf("alice computed %d times after second wave, want 2", n)
+	}
+}
+
+func TestMaterializerPendingCap(t *testing.T) {
+	m := newTestMaterializer(newFakeStore())
+	for i := range maxPending + 100 {
+		m.Enqueue(strconv.Itoa(i))
+	}
+	// duplikat ne zauzima novo mesto, a višak se odbacuje
+	m.Enqueue("0", "new")
+	if len(m.pending) != maxPending {
+		t.Fatalf("pending = %d, want %d", len(m.pending), maxPending)
+	}
+	if _, ok := m.pending["new"]; ok {
+		t.Error("user enqueued beyond maxPending")
+	}
+
+	m.drainPending(context.Background())
+	if len(m.pending) != 0 {
+		t.Errorf("pending after drain = %d, want 0", len(m.pending))
+	}
+}
+
+func TestMaterializerRefreshStale(t *testing.T) {
+	store := newFakeStore()
+	store.stale = []string{"alice", "bob"}
+	m := newTestMaterializer(store)
+
+	before := time.Now()
+	m.refreshStale(context.Background())
+	after := time.Now()
+	waitSaved(t, store, "alice", "bob")
+
+	cfg := testConfig()
+	within := func(got time.Time, ago time.Duration) bool {
+		return !got.Before(before.Add(-ago)) && !got.After(after.Add(-ago))
+	}
+	if !within(store.activeSince, cfg.ActiveWindow) {
+		t.Errorf("activeSince = %v, want now-%v", store.activeSince, cfg.ActiveWindow)
+	}
+	if !within(store.staleBefore, cfg.RefreshInterval) {
+		t.Errorf("staleBefore = %v, want now-%v", store.staleBefore, cfg.RefreshInterval)
+	}
+	if store.staleLimit != cfg.BatchSize {
+		t.Errorf("limit = %d, want %d", store.staleLimit, cfg.BatchSize)
+	}
+}
+
+// Neuspelo računanje ne upisuje ništa: stari skup ostaje, a servis (posle MaxAge)
+// računa uživo dok sledeći prolaz ne uspe.
+func TestMaterializerFailedRefreshKeepsOldSet(t *testing.T) {
+	store := newFakeStore()
+	store.fail["alice"] = true
+	old := []model.Recommendation{{UserID: "old"}}
+	store.saved["alice"] = old
+	m := newTestMaterializer(store)
+	m.OnRefresh = func(ctx context.Context, userID string) {
+		t.Errorf("OnRefresh called for %s after failed compute", userID)
+	}
+
+	m.refresh(context.Background(), "alice")
+
+	if n := store.computedCount("alice"); n != 1 {
+		t.Fatalf("computed %d times, want 1", n)
+	}
+	if got := store.saved["alice"]; len(got) != 1 || got[0].UserID != "old" {
+		t.Errorf("saved = %+v, want the old set kept", got)
+	}
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"database-example/model"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Block pravi (blocker)-[:BLOCKS]->(blocked) i briše praćenje u oba smera.
+// Obrisane FOLLOWS veze se, kao kod Unfollow-a, beleže u FOLLOW_HISTORY u istoj
+// transakciji, da churn i aktivnost vide i prekide nastale blokiranjem.
+func (r *FollowerRepository) Block(ctx context.Context, blockerID, blockedID string) (err error) {
+	ctx, q := r.startQuery(ctx, "Block")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeWrite)
+	defer ses.Close(ctx)
+
+	_, err = ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (a:User {id: $blockerID})
+			MATCH (b:User {id: $blockedID})
+			MERGE (a)-[r:BLOCKS]->(b)
+			ON CREATE SET r.since = datetime()
+			WITH a, b
+			OPTIONAL MATCH (a)-[f:FOLLOWS]-(b)
+			WITH f, startNode(f) AS follower, endNode(f) AS followee
+			FOREACH (_ IN CASE WHEN f IS NULL THEN [] ELSE [1] END |
+				MERGE (follower)-[h:FOLLOW_HISTORY]->(followee)
+				SET h.unfollows = [t IN coalesce(h.unfollows, []) WHERE t > datetime($now) - duration({seconds: $retention})] + datetime($now),
+				    follower.lastFollowActivityAt = datetime($now)
+			)
+			DELETE f
+			RETURN count(f) AS unfollowed
+		`, map[string]any{
+			"blockerID": blockerID,
+			"blockedID": blockedID,
+			"now":       time.Now().UTC().Format(time.RFC3339),
+			"retention": int64(followHistoryRetention.Seconds()),
+		})
+		if err != nil {
+			return nil, err
+		}
+		// bez reda = neki od MATCH-eva nije uspeo
+		if !res.Next(ctx) {
+			if res.Err() != nil {
+				return nil, res.Err()
+			}
+			return nil, ErrUserNotFound
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (r *FollowerRepository) Unblock(ctx context.Context, blockerID, blockedID string) (err error) {
+	ctx, q := r.startQuery(ctx, "Unblock")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeWrite)
+	defer ses.Close(ctx)
+
+	_, err = ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (:User {id: $blockerID})-[r:BLOCKS]->(:User {id: $blockedID})
+			DELETE r
+			RETURN count(r) AS deleted
+		`, map[string]any{
+			"blockerID": blockerID,
+			"blockedID": blockedID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rec, err := res.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+		deleted, _ := rec.Get("deleted")
+		if n, ok := deleted.(int64); !ok || n == 0 {
+			return nil, ErrNotBlocked
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// GetFollowCounts vraća broj pratilaca i praćenih; ErrUserNotFound ako user ne postoji.
+func (r *FollowerRepository) GetFollowCounts(ctx context.Context, userID string) (_ model.FollowCounts, err error) {
+	ctx, q := r.startQuery(ctx, "GetFollowCounts")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	resAny, err := ses.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (u:User {id: $userId})
+			RETURN size([(u)<-[:FOLLOWS]-(:User) | 1]) AS followers,
+			       size([(u)-[:FOLLOWS]->(:User) | 1]) AS followees
+		`, map[string]any{"userId": userID})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			if res.Err() != nil {
+				return nil, res.Err()
+			}
+			return nil, ErrUserNotFound
+		}
+		rec := res.Record()
+		followers, _ := rec.Get("followers")
+		followees, _ := rec.Get("followees")
+		return model.FollowCounts{
+			UserID:    userID,
+			Followers: followers.(int64),
+			Followees: followees.(int64),
+		}, nil
+	})
+	if err != nil {
+		return model.FollowCounts{}, err
+	}
+	return resAny.(model.FollowCounts), nil
+}
+
+// checkNotBlocked vraća ErrUserNotFound ako neki od korisnika ne postoji,
+// odnosno ErrBlocked ako postoji BLOCKS u bilo kom smeru.
+func checkNotBlocked(ctx context.Context, tx neo4j.ManagedTransaction, aID, bID string) error {
+	res, err := tx.Run(ctx, `
+		MATCH (a:User {id: $aID})
+		MATCH (b:User {id: $bID})
+		RETURN EXISTS { MATCH (a)-[:BLOCKS]-(b) } AS blocked
+	`, map[string]any{"aID": aID, "bID": bID})
+	if err != nil {
+		return err
+	}
+	if !res.Next(ctx) {
+		if res.Err() != nil {
+			return res.Err()
+		}
+		return ErrUserNotFound
+	}
+	blocked, _ := res.Record().Get("blocked")
+	if b, ok := blocked.(bool); ok && b {
+		return ErrBlocked
+	}
+	return nil
+}
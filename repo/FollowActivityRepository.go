@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"database-example/config"
+	"database-example/model"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// followHistoryRetention: koliko dugo (f)-[:FOLLOW_HISTORY]->(u) pamti vremena
+// praćenja/otpraćivanja. Starije vrednosti se odsecaju pri sledećem upisu.
+const followHistoryRetention = config.MaxChurnWindow
+
+// GetFollowActivity vraća podatke potrebne za follow politiku: broj followee-a,
+// broj novih praćenja u poslednja 24h i (ako je targetID zadat) da li user već
+// prati target-a i koliko je follow/unfollow promena nad njim bilo u churnWindow.
+func (r *FollowerRepository) GetFollowActivity(ctx context.Context, userID, targetID string, churnWindow time.Duration) (_ model.FollowActivity, err error) {
+	ctx, q := r.startQuery(ctx, "GetFollowActivity")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	resAny, err := ses.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return followActivity(ctx, tx, userID, targetID, churnWindow)
+	})
+	if err != nil {
+		return model.FollowActivity{}, err
+	}
+	return resAny.(model.FollowActivity), nil
+}
+
+// followActivity čita FollowActivity u datoj transakciji (i u Follow, pod
+// lock-om na follower-u, da bi provera politike i upis bili atomični).
+func followActivity(ctx context.Context, tx neo4j.ManagedTransaction, userID, targetID string, churnWindow time.Duration) (model.FollowActivity, error) {
+	res, err := tx.Run(ctx, `
+		MATCH (f:User {id: $userId})
+		OPTIONAL MATCH (f)-[h:FOLLOW_HISTORY]->(:User)
+		WITH f, sum(size([t IN coalesce(h.follows, []) WHERE t > datetime($now) - duration({days: 1})])) AS followsLastDay
+		OPTIONAL MATCH (f)-[p:FOLLOW_HISTORY]->(:User {id: $targetId})
+		RETURN size([(f)-[:FOLLOWS]->(:User) | 1]) AS followees,
+		       followsLastDay,
+		       EXISTS { MATCH (f)-[:FOLLOWS]->(:User {id: $targetId}) } AS following,
+		       size([t IN coalesce(p.follows, []) + coalesce(p.unfollows, [])
+		             WHERE t > datetime($now) - duration({seconds: $churnWindow})]) AS recentChanges
+	`, map[string]any{
+		"userId":      userID,
+		"targetId":    targetID,
+		"now":         time.Now().UTC().Format(time.RFC3339),
+		"churnWindow": int64(churnWindow.Seconds()),
+	})
+	if err != nil {
+		return model.FollowActivity{}, err
+	}
+	if !res.Next(ctx) {
+		if res.Err() != nil {
+			return model.FollowActivity{}, res.Err()
+		}
+		return model.FollowActivity{}, ErrUserNotFound
+	}
+	rec := res.Record()
+	followees, _ := rec.Get("followees")
+	followsLastDay, _ := rec.Get("followsLastDay")
+	following, _ := rec.Get("following")
+	recentChanges, _ := rec.Get("recentChanges")
+	return model.FollowActivity{
+		Followees:      followees.(int64),
+		FollowsLastDay: followsLastDay.(int64),
+		Following:      following.(bool),
+		RecentChanges:  recentChanges.(int64),
+	}, nil
+}
+
+// lockUser uzima write lock na User čvoru do kraja transakcije (SET+REMOVE ne
+// menja podatke), da bi se upisi istog korisnika izvršavali jedan za drugim.
+func lockUser(ctx context.Context, tx neo4j.ManagedTransaction, userID string) error {
+	res, err := tx.Run(ctx, `
+		MATCH (u:User {id: $userId})
+		SET u._lock = true
+		REMOVE u._lock
+	`, map[string]any{"userId": userID})
+	if err != nil {
+		return err
+	}
+	_, err = res.Consume(ctx)
+	return err
+}
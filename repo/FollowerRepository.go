@@ -2,78 +2,198 @@ package repo
 
 import (
 	"context"
-	"errors"
-	"log"
-	"os"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
+	"database-example/config"
+	"database-example/logging"
+	"database-example/metrics"
 	"database-example/model"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
 type FollowerRepository struct {
-	driver neo4j.DriverWithContext
-	logger *log.Logger
+	driver   neo4j.DriverWithContext
+	logger   *slog.Logger
+	database string
+	cfg      config.Neo4jConfig
+	ready    atomic.Bool
 }
 
-func NewFollowerRepository(logger *log.Logger) (*FollowerRepository, error) {
-	// Podrži oba seta imena env varijabli (kao u Stakeholders i kao u ranijem compose-u)
-	uri := firstNonEmpty(os.Getenv("NEO4J_DB"), os.Getenv("NEO4J_URI"))
-	user := firstNonEmpty(os.Getenv("NEO4J_USERNAME"), os.Getenv("NEO4J_USER"))
-	pass := os.Getenv("NEO4J_PASS")
+// NewFollowerRepository samo pravi drajver (bez mrežnih poziva); konekcija i
+// migracije šeme se rade u Connect.
+func NewFollowerRepository(cfg config.Config, logger *slog.Logger) (*FollowerRepository, error) {
+	dbCfg := cfg.Neo4j
 
-	auth := neo4j.BasicAuth(user, pass, "")
-	driver, err := neo4j.NewDriverWithContext(uri, auth)
+	uri, withTLS, err := driverTLS(dbCfg.URI, dbCfg.TLS)
 	if err != nil {
-		logger.Panic(err)
 		return nil, err
 	}
 
-	// Brza provera konekcije (kao safety)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := driver.VerifyConnectivity(ctx); err != nil {
-		_ = driver.Close(ctx)
-		logger.Panic(err)
-		return nil, err
+	auth := neo4j.BasicAuth(dbCfg.Username, dbCfg.Password, "")
+	driver, err := neo4j.NewDriverWithContext(uri, auth, func(c *neo4j.Config) {
+		c.MaxConnectionPoolSize = dbCfg.MaxConnectionPoolSize
+		c.SocketConnectTimeout = dbCfg.ConnectTimeout
+		c.ConnectionAcquisitionTimeout = dbCfg.ConnectionAcquisitionTimeout
+		c.MaxTransactionRetryTime = dbCfg.MaxTransactionRetryTime
+		c.MaxConnectionLifetime = dbCfg.MaxConnectionLifetime
+		c.Log = logging.Neo4jLogger{Logger: logger.With("component", "neo4j")}
+	}, withTLS)
+	if err != nil {
+		return nil, fmt.Errorf("create neo4j driver: %w", err)
 	}
+	metrics.Neo4jPoolMaxSize.Set(float64(dbCfg.MaxConnectionPoolSize))
 
 	return &FollowerRepository{
-		driver: driver,
-		logger: logger,
+		driver:   driver,
+		logger:   logger,
+		database: dbCfg.Database,
+		cfg:      dbCfg,
 	}, nil
 }
 
+// Connect čeka da baza postane dostupna (eksponencijalni backoff iz
+// Neo4jConfig.ConnectRetry), primenjuje migracije i tek onda označava repo kao spreman.
+func (r *FollowerRepository) Connect(ctx context.Context) error {
+	retry := r.cfg.ConnectRetry
+	backoff := retry.InitialBackoff
+
+	var err error
+	for attempt := 1; attempt <= retry.MaxAttempts; attempt++ {
+		verifyCtx, cancel := context.WithTimeout(ctx, r.cfg.ConnectTimeout)
+		err = r.driver.VerifyConnectivity(verifyCtx)
+		cancel()
+		if err == nil {
+			break
+		}
+		if attempt == retry.MaxAttempts {
+			return fmt.Errorf("neo4j not reachable after %d attempts: %w", attempt, err)
+		}
+		r.logger.Warn("neo4j not reachable, retrying",
+			"attempt", attempt,
+			"max_attempts", retry.MaxAttempts,
+			"backoff", backoff,
+			"error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(time.Duration(float64(backoff)*retry.Multiplier), retry.MaxBackoff)
+	}
+
+	// constraint-i i indeksi; idempotentno, pa je bezbedno na svakom startu
+	migrateCtx, cancelMigrate := context.WithTimeout(ctx, r.cfg.MigrationTimeout)
+	defer cancelMigrate()
+	if _, err := r.Migrate(migrateCtx); err != nil {
+		return err
+	}
+
+	r.ready.Store(true)
+	metrics.Neo4jReady.Set(1)
+	return nil
+}
+
+// Ready je true kada je Connect uspešno završen.
+func (r *FollowerRepository) Ready() bool {
+	return r.ready.Load()
+}
+
+// session otvara sesiju nad konfigurisanom bazom. Ako ctx nosi BookmarkTracker,
+// sesija počinje od klijentovih bookmark-a, a write sesija po zatvaranju
+// upisuje svoj bookmark u tracker.
+func (r *FollowerRepository) session(ctx context.Context, mode neo4j.AccessMode) neo4j.SessionWithContext {
+	cfg := neo4j.SessionConfig{
+		AccessMode:   mode,
+		DatabaseName: r.database,
+	}
+	tracker := bookmarkTracker(ctx)
+	if tracker != nil {
+		cfg.Bookmarks = tracker.start()
+		if mode != neo4j.AccessModeWrite {
+			tracker = nil
+		}
+	}
+	return newTrackedSession(r.driver.NewSession(ctx, cfg), tracker)
+}
+
 func (r *FollowerRepository) Close(ctx context.Context) error {
+	r.ready.Store(false)
+	metrics.Neo4jReady.Set(0)
 	return r.driver.Close(ctx)
 }
 
 // Health: koristi se u Ping da proveri bazu
 func (r *FollowerRepository) Health(ctx context.Context) error {
+	if !r.Ready() {
+		return ErrNotReady
+	}
 	return r.driver.VerifyConnectivity(ctx)
 }
 
-var (
-	ErrNotFollowing = errors.New("follow relationship does not exist")
-)
+// koliko zajedničkih followee-a vraćamo kao objašnjenje preporuke
+const recommendationViaLimit = 3
+
+// FollowCheck proverava follow politiku unutar Follow transakcije: follower je
+// zaključan do commit-a, pa istovremeni Follow pozivi istog korisnika ne mogu
+// svi da prođu proveru i zajedno pređu limit.
+type FollowCheck struct {
+	ChurnWindow time.Duration
+	// Allow dobija aktivnost pročitanu pod lock-om; greška odbija praćenje
+	Allow func(model.FollowActivity) error
+}
 
-func (r *FollowerRepository) Follow(ctx context.Context, followerID, followeeID string) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+// Follow upisuje praćenje; check nil znači bez follow politike.
+func (r *FollowerRepository) Follow(ctx context.Context, followerID, followeeID string, check *FollowCheck) (err error) {
+	ctx, q := r.startQuery(ctx, "Follow")
+	defer q.end(&err)
+
+	session := r.session(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// blokada u bilo kom smeru zabranjuje praćenje
+		if err := checkNotBlocked(ctx, tx, followerID, followeeID); err != nil {
+			return nil, err
+		}
+		if check != nil {
+			if err := lockUser(ctx, tx, followerID); err != nil {
+				return nil, err
+			}
+			activity, err := followActivity(ctx, tx, followerID, followeeID, check.ChurnWindow)
+			if err != nil {
+				return nil, err
+			}
+			if err := check.Allow(activity); err != nil {
+				return nil, err
+			}
+		}
+
+		// novo praćenje se upisuje i u FOLLOW_HISTORY (dnevna kvota, churn)
 		const cypher = `
 			MATCH (f:User {id: $followerID})
 			MATCH (u:User {id: $followeeID})
+			OPTIONAL MATCH (f)-[existing:FOLLOWS]->(u)
+			WITH f, u, existing IS NOT NULL AS already
 			MERGE (f)-[r:FOLLOWS]->(u)
 			ON CREATE SET r.since = datetime($now)
+			FOREACH (_ IN CASE WHEN already THEN [] ELSE [1] END |
+				MERGE (f)-[h:FOLLOW_HISTORY]->(u)
+				SET h.follows = [t IN coalesce(h.follows, []) WHERE t > datetime($now) - duration({seconds: $retention})] + datetime($now),
+				    f.lastFollowActivityAt = datetime($now)
+			)
 			RETURN 1 AS ok
 		`
 		params := map[string]any{
 			"followerID": followerID,
 			"followeeID": followeeID,
 			"now":        time.Now().UTC().Format(time.RFC3339),
+			"retention":  int64(followHistoryRetention.Seconds()),
 		}
 
 		res, err := tx.Run(ctx, cypher, params)
@@ -92,24 +212,28 @@ func (r *FollowerRepository) Follow(ctx context.Context, followerID, followeeID
 	return err
 }
 
-func (r *FollowerRepository) Unfollow(ctx context.Context, followerID, followeeID string) error {
-	// zaštita od self-unfollow; može i u servisu ako hoćeš
-	if followerID == followeeID {
-		return errors.New("cannot unfollow self")
-	}
+func (r *FollowerRepository) Unfollow(ctx context.Context, followerID, followeeID string) (err error) {
+	ctx, q := r.startQuery(ctx, "Unfollow")
+	defer q.end(&err)
 
-	ses := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	ses := r.session(ctx, neo4j.AccessModeWrite)
 	defer ses.Close(ctx)
 
-	_, err := ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+	_, err = ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		// ako nema takve relacije, deleted=0
 		res, err := tx.Run(ctx, `
-			MATCH (:User {id:$followerID})-[r:FOLLOWS]->(:User {id:$followeeID})
+			MATCH (f:User {id:$followerID})-[r:FOLLOWS]->(u:User {id:$followeeID})
 			DELETE r
-			RETURN COUNT(r) AS deleted
+			WITH f, u
+			MERGE (f)-[h:FOLLOW_HISTORY]->(u)
+			SET h.unfollows = [t IN coalesce(h.unfollows, []) WHERE t > datetime($now) - duration({seconds: $retention})] + datetime($now),
+			    f.lastFollowActivityAt = datetime($now)
+			RETURN count(*) AS deleted
 		`, map[string]any{
 			"followerID": followerID,
 			"followeeID": followeeID,
+			"now":        time.Now().UTC().Format(time.RFC3339),
+			"retention":  int64(followHistoryRetention.Seconds()),
 		})
 		if err != nil {
 			return nil, err
@@ -127,24 +251,28 @@ func (r *FollowerRepository) Unfollow(ctx context.Context, followerID, followeeI
 	return err
 }
 
-func (r *FollowerRepository) GetRecommendations(ctx context.Context, userID string, limit int) ([]model.Recommendation, error) {
+func (r *FollowerRepository) GetRecommendations(ctx context.Context, userID string, limit int) (_ []model.Recommendation, err error) {
+	ctx, q := r.startQuery(ctx, "GetRecommendations")
+	defer q.end(&err)
+
 	if limit <= 0 {
 		limit = 10
 	}
 
-	ses := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	ses := r.session(ctx, neo4j.AccessModeRead)
 	defer ses.Close(ctx)
 
 	recsAny, err := ses.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		res, err := tx.Run(ctx, `
-            MATCH (me:User {id: $userId})-[:FOLLOWS]->(:User)-[:FOLLOWS]->(cand:User)
+            MATCH (me:User {id: $userId})-[:FOLLOWS]->(mid:User)-[:FOLLOWS]->(cand:User)
             WHERE cand.id <> $userId
               AND NOT (me)-[:FOLLOWS]->(cand)
-            WITH cand, count(*) AS mutual
-            RETURN cand.id AS user_id, mutual
+              AND NOT EXISTS { MATCH (me)-[:BLOCKS]-(cand) }
+            WITH cand, count(*) AS mutual, collect(DISTINCT mid.id) AS via
+            RETURN cand.id AS user_id, mutual, via[0..$viaLimit] AS via
             ORDER BY mutual DESC
             LIMIT $limit
-        `, map[string]any{"userId": userID, "limit": limit})
+        `, map[string]any{"userId": userID, "limit": limit, "viaLimit": recommendationViaLimit})
 		if err != nil {
 			return nil, err
 		}
@@ -154,9 +282,19 @@ func (r *FollowerRepository) GetRecommendations(ctx context.Context, userID stri
 			rec := res.Record()
 			id, _ := rec.Get("user_id")
 			mutual, _ := rec.Get("mutual")
+			viaAny, _ := rec.Get("via")
+			via := make([]string, 0)
+			if list, ok := viaAny.([]any); ok {
+				for _, v := range list {
+					if s, ok := v.(string); ok {
+						via = append(via, s)
+					}
+				}
+			}
 			out = append(out, model.Recommendation{
 				UserID: id.(string),
 				Mutual: mutual.(int64),
+				Via:    via,
 			})
 		}
 		return out, res.Err()
@@ -164,10 +302,45 @@ func (r *FollowerRepository) GetRecommendations(ctx context.Context, userID stri
 	if err != nil {
 		return nil, err
 	}
-	return recsAny.([]model.Recommendation), nil
+	recs := recsAny.([]model.Recommendation)
+	q.rows(len(recs))
+	return recs, nil
+}
+
+func (r *FollowerRepository) GetFollowees(ctx context.Context, userID string, skip, limit int) (_ model.UserPage, err error) {
+	ctx, q := r.startQuery(ctx, "GetFollowees")
+	defer q.end(&err)
+
+	return r.userPage(ctx, q, `
+		MATCH (:User {id:$userId})-[:FOLLOWS]->(f:User)
+		RETURN f.id AS id
+		ORDER BY id
+		SKIP $skip LIMIT $limit
+	`, `
+		MATCH (u:User {id:$userId})
+		RETURN size([(u)-[:FOLLOWS]->(:User) | 1]) AS total
+	`, userID, skip, limit)
+}
+
+func (r *FollowerRepository) GetFollowers(ctx context.Context, userID string, skip, limit int) (_ model.UserPage, err error) {
+	ctx, q := r.startQuery(ctx, "GetFollowers")
+	defer q.end(&err)
+
+	return r.userPage(ctx, q, `
+		MATCH (f:User)-[:FOLLOWS]->(:User {id:$userId})
+		RETURN f.id AS id
+		ORDER BY id
+		SKIP $skip LIMIT $limit
+	`, `
+		MATCH (u:User {id:$userId})
+		RETURN size([(u)<-[:FOLLOWS]-(:User) | 1]) AS total
+	`, userID, skip, limit)
 }
 
-func (r *FollowerRepository) GetFollowees(ctx context.Context, userID string, skip, limit int) ([]string, error) {
+// userPage čita jednu stranu ID-jeva (pageCypher vraća "id") i ukupan broj
+// (countCypher vraća "total") u istoj transakciji. Kad je strana nepotpuna,
+// ukupan broj je poznat bez drugog upita.
+func (r *FollowerRepository) userPage(ctx context.Context, q *query, pageCypher, countCypher, userID string, skip, limit int) (model.UserPage, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -175,48 +348,58 @@ func (r *FollowerRepository) GetFollowees(ctx context.Context, userID string, sk
 		skip = 0
 	}
 
-	ses := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	ses := r.session(ctx, neo4j.AccessModeRead)
 	defer ses.Close(ctx)
 
 	resAny, err := ses.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		res, err := tx.Run(ctx, `
-			MATCH (:User {id:$userId})-[:FOLLOWS]->(f:User)
-			RETURN f.id AS id
-			ORDER BY id
-			SKIP $skip LIMIT $limit
-		`, map[string]any{
+		params := map[string]any{
 			"userId": userID,
 			"skip":   skip,
 			"limit":  limit,
-		})
+		}
+		res, err := tx.Run(ctx, pageCypher, params)
 		if err != nil {
 			return nil, err
 		}
 
-		out := make([]string, 0)
+		page := model.UserPage{UserIDs: make([]string, 0)}
 		for res.Next(ctx) {
 			idVal, _ := res.Record().Get("id")
-			out = append(out, idVal.(string))
+			page.UserIDs = append(page.UserIDs, idVal.(string))
 		}
-		return out, res.Err()
+		if err := res.Err(); err != nil {
+			return nil, err
+		}
+
+		n := len(page.UserIDs)
+		if n < limit && (n > 0 || skip == 0) {
+			page.TotalCount = int64(skip + n)
+			return page, nil
+		}
+		res, err = tx.Run(ctx, countCypher, params)
+		if err != nil {
+			return nil, err
+		}
+		// nepostojeći korisnik ima praznu listu (kao i do sada), pa total ostaje 0
+		if res.Next(ctx) {
+			total, _ := res.Record().Get("total")
+			page.TotalCount = total.(int64)
+		}
+		if err := res.Err(); err != nil {
+			return nil, err
+		}
+		page.HasMore = int64(skip+n) < page.TotalCount
+		return page, nil
 	})
 	if err != nil {
-		return nil, err
+		return model.UserPage{}, err
 	}
-	return resAny.([]string), nil
+	page := resAny.(model.UserPage)
+	q.rows(len(page.UserIDs))
+	return page, nil
 }
 
 /* — Slede metode koje ćemo dodati kasnije —
 func (r *FollowerRepository) Unfollow(ctx context.Context, followerID, followeeID string) error { ... }
-func (r *FollowerRepository) ListFollowers(ctx context.Context, userID string, limit, offset int) ([]string, error) { ... }
 func (r *FollowerRepository) ListFollowing(ctx context.Context, userID string, limit, offset int) ([]string, error) { ... }
 */
-
-func firstNonEmpty(vals ...string) string {
-	for _, v := range vals {
-		if v != "" {
-			return v
-		}
-	}
-	return ""
-}
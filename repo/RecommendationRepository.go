@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"database-example/model"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// SaveRecommendations zamenjuje precomputed preporuke korisnika sa
+// (u)-[:RECOMMENDED {rank, mutual, via}]->(c) i beleži vreme računanja.
+func (r *FollowerRepository) SaveRecommendations(ctx context.Context, userID string, recs []model.Recommendation, computedAt time.Time) (err error) {
+	ctx, q := r.startQuery(ctx, "SaveRecommendations")
+	defer q.end(&err)
+	q.batch(len(recs))
+
+	rows := make([]map[string]any, 0, len(recs))
+	for i, rec := range recs {
+		rows = append(rows, map[string]any{
+			"userId": rec.UserID,
+			"mutual": rec.Mutual,
+			"via":    rec.Via,
+			"rank":   i,
+		})
+	}
+
+	ses := r.session(ctx, neo4j.AccessModeWrite)
+	defer ses.Close(ctx)
+
+	_, err = ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (u:User {id: $userId})
+			SET u.recommendationsComputedAt = datetime($computedAt)
+			WITH u
+			OPTIONAL MATCH (u)-[old:RECOMMENDED]->()
+			DELETE old
+			WITH DISTINCT u
+			UNWIND $rows AS row
+			MATCH (c:User {id: row.userId})
+			CREATE (u)-[:RECOMMENDED {rank: row.rank, mutual: row.mutual, via: row.via}]->(c)
+		`, map[string]any{
+			"userId":     userID,
+			"computedAt": computedAt.UTC().Format(time.RFC3339Nano),
+			"rows":       rows,
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = res.Consume(ctx)
+		return nil, err
+	})
+	return err
+}
+
+// GetPrecomputedRecommendations vraća sačuvane preporuke (bez onih koje je user u
+// međuvremenu zapratio ili blokirao). found=false ako za usera još nisu izračunate.
+func (r *FollowerRepository) GetPrecomputedRecommendations(ctx context.Context, userID string, limit int) (_ model.RecommendationSet, found bool, err error) {
+	ctx, q := r.startQuery(ctx, "GetPrecomputedRecommendations")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	resAny, err := ses.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (me:User {id: $userId})
+			WHERE me.recommendationsComputedAt IS NOT NULL
+			OPTIONAL MATCH (me)-[r:RECOMMENDED]->(c:User)
+			WHERE NOT (me)-[:FOLLOWS]->(c)
+			  AND NOT EXISTS { MATCH (me)-[:BLOCKS]-(c) }
+			WITH me, r, c
+			ORDER BY r.rank
+			WITH me, collect(CASE WHEN c IS NULL THEN null ELSE {user_id: c.id, mutual: r.mutual, via: r.via} END) AS recs
+			RETURN me.recommendationsComputedAt AS computed_at, recs[0..$limit] AS recs
+		`, map[string]any{"userId": userID, "limit": limit})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			return nil, res.Err()
+		}
+		rec := res.Record()
+		computedAt, _ := rec.Get("computed_at")
+		recsAny, _ := rec.Get("recs")
+
+		set := model.RecommendationSet{
+			Items:       make([]model.Recommendation, 0),
+			Precomputed: true,
+		}
+		if t, ok := computedAt.(time.Time); ok {
+			set.ComputedAt = t.UTC()
+		}
+		list, _ := recsAny.([]any)
+		for _, item := range list {
+			m, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			id, _ := m["user_id"].(string)
+			mutual, _ := m["mutual"].(int64)
+			via := make([]string, 0)
+			if vs, ok := m["via"].([]any); ok {
+				for _, v := range vs {
+					if s, ok := v.(string); ok {
+						via = append(via, s)
+					}
+				}
+			}
+			set.Items = append(set.Items, model.Recommendation{UserID: id, Mutual: mutual, Via: via})
+		}
+		return set, nil
+	})
+	if err != nil || resAny == nil {
+		return model.RecommendationSet{}, false, err
+	}
+	set := resAny.(model.RecommendationSet)
+	q.rows(len(set.Items))
+	return set, true, nil
+}
+
+// StaleRecommendationUsers vraća aktivne korisnike (follow/unfollow posle activeSince)
+// čije preporuke nisu računate ili su starije od staleBefore, najstarije prvo.
+// Kreće od indeksa na User.lastFollowActivityAt (postavljaju ga Follow, Unfollow
+// i Block), pa upit obilazi samo aktivne korisnike, ne ceo graf.
+func (r *FollowerRepository) StaleRecommendationUsers(ctx context.Context, activeSince, staleBefore time.Time, limit int) (_ []string, err error) {
+	ctx, q := r.startQuery(ctx, "StaleRecommendationUsers")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	resAny, err := ses.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (u:User)
+			WHERE u.lastFollowActivityAt > datetime($activeSince)
+			  AND (u.recommendationsComputedAt IS NULL OR u.recommendationsComputedAt < datetime($staleBefore))
+			RETURN u.id AS id
+			ORDER BY coalesce(u.recommendationsComputedAt, datetime('1970-01-01T00:00:00Z'))
+			LIMIT $limit
+		`, map[string]any{
+			"activeSince": activeSince.UTC().Format(time.RFC3339),
+			"staleBefore": staleBefore.UTC().Format(time.RFC3339),
+			"limit":       limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]string, 0)
+		for res.Next(ctx) {
+			idVal, _ := res.Record().Get("id")
+			if id, ok := idVal.(string); ok {
+				out = append(out, id)
+			}
+		}
+		return out, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := resAny.([]string)
+	q.rows(len(ids))
+	return ids, nil
+}
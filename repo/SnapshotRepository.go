@@ -0,0 +1,259 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"database-example/model"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// ExportUsers streamuje sve User čvorove. Koristi auto-commit upit (ne ExecuteRead)
+// da se callback ne bi ponovo pozivao ako driver retry-uje transakciju.
+func (r *FollowerRepository) ExportUsers(ctx context.Context, fn func(id string) error) (err error) {
+	ctx, q := r.startQuery(ctx, "ExportUsers")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	res, err := ses.Run(ctx, `
+		MATCH (u:User)
+		RETURN u.id AS id
+		ORDER BY id
+	`, nil)
+	if err != nil {
+		return err
+	}
+	n := 0
+	defer func() { q.rows(n) }()
+	for res.Next(ctx) {
+		idVal, _ := res.Record().Get("id")
+		id, ok := idVal.(string)
+		if !ok {
+			// čvor bez id-ja nema smisla izvoziti
+			continue
+		}
+		if err := fn(id); err != nil {
+			return err
+		}
+		n++
+	}
+	return res.Err()
+}
+
+// ExportFollows streamuje sve FOLLOWS veze zajedno sa `since`.
+func (r *FollowerRepository) ExportFollows(ctx context.Context, fn func(model.FollowEdge) error) (err error) {
+	ctx, q := r.startQuery(ctx, "ExportFollows")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	res, err := ses.Run(ctx, `
+		MATCH (a:User)-[r:FOLLOWS]->(b:User)
+		RETURN a.id AS follower_id, b.id AS followee_id, r.since AS since
+		ORDER BY follower_id, followee_id
+	`, nil)
+	if err != nil {
+		return err
+	}
+	n := 0
+	defer func() { q.rows(n) }()
+	for res.Next(ctx) {
+		rec := res.Record()
+		followerVal, _ := rec.Get("follower_id")
+		followeeVal, _ := rec.Get("followee_id")
+		sinceVal, _ := rec.Get("since")
+
+		follower, ok1 := followerVal.(string)
+		followee, ok2 := followeeVal.(string)
+		if !ok1 || !ok2 {
+			continue
+		}
+		edge := model.FollowEdge{FollowerID: follower, FolloweeID: followee}
+		if since, ok := sinceVal.(time.Time); ok {
+			since = since.UTC()
+			edge.Since = &since
+		}
+		if err := fn(edge); err != nil {
+			return err
+		}
+		n++
+	}
+	return res.Err()
+}
+
+// ImportUsers idempotentno kreira User čvorove u jednoj transakciji.
+func (r *FollowerRepository) ImportUsers(ctx context.Context, ids []string) (err error) {
+	ctx, q := r.startQuery(ctx, "ImportUsers")
+	defer q.end(&err)
+
+	if len(ids) == 0 {
+		return nil
+	}
+	q.batch(len(ids))
+
+	ses := r.session(ctx, neo4j.AccessModeWrite)
+	defer ses.Close(ctx)
+
+	_, err = ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			UNWIND $ids AS id
+			MERGE (:User {id: id})
+		`, map[string]any{"ids": ids})
+		if err != nil {
+			return nil, err
+		}
+		_, err = res.Consume(ctx)
+		return nil, err
+	})
+	return err
+}
+
+// ImportFollows idempotentno kreira FOLLOWS veze (i krajnje čvorove ako fale).
+// Postojećim vezama se `since` ne menja. Par koji u bazi već ima BLOCKS (u bilo
+// kom smeru) se preskače, kao što bi ga odbio i Follow; vraća broj preskočenih.
+func (r *FollowerRepository) ImportFollows(ctx context.Context, edges []model.FollowEdge) (skipped int, err error) {
+	ctx, q := r.startQuery(ctx, "ImportFollows")
+	defer q.end(&err)
+
+	if len(edges) == 0 {
+		return 0, nil
+	}
+	q.batch(len(edges))
+
+	rows := make([]map[string]any, 0, len(edges))
+	for _, e := range edges {
+		var since any
+		if e.Since != nil {
+			since = e.Since.UTC().Format(time.RFC3339Nano)
+		}
+		rows = append(rows, map[string]any{
+			"followerID": e.FollowerID,
+			"followeeID": e.FolloweeID,
+			"since":      since,
+		})
+	}
+
+	ses := r.session(ctx, neo4j.AccessModeWrite)
+	defer ses.Close(ctx)
+
+	imported, err := ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			UNWIND $rows AS row
+			MERGE (f:User {id: row.followerID})
+			MERGE (u:User {id: row.followeeID})
+			WITH f, u, row
+			WHERE NOT EXISTS { MATCH (f)-[:BLOCKS]-(u) }
+			MERGE (f)-[r:FOLLOWS]->(u)
+			ON CREATE SET r.since = CASE WHEN row.since IS NULL THEN datetime() ELSE datetime(row.since) END
+			RETURN count(*) AS imported
+		`, map[string]any{"rows": rows})
+		if err != nil {
+			return nil, err
+		}
+		rec, err := res.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+		n, _ := rec.Get("imported")
+		return n, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	n, _ := imported.(int64)
+	return len(edges) - int(n), nil
+}
+
+// ExportBlocks streamuje sve BLOCKS veze zajedno sa `since`.
+func (r *FollowerRepository) ExportBlocks(ctx context.Context, fn func(model.BlockEdge) error) (err error) {
+	ctx, q := r.startQuery(ctx, "ExportBlocks")
+	defer q.end(&err)
+
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	res, err := ses.Run(ctx, `
+		MATCH (a:User)-[r:BLOCKS]->(b:User)
+		RETURN a.id AS blocker_id, b.id AS blocked_id, r.since AS since
+		ORDER BY blocker_id, blocked_id
+	`, nil)
+	if err != nil {
+		return err
+	}
+	n := 0
+	defer func() { q.rows(n) }()
+	for res.Next(ctx) {
+		rec := res.Record()
+		blockerVal, _ := rec.Get("blocker_id")
+		blockedVal, _ := rec.Get("blocked_id")
+		sinceVal, _ := rec.Get("since")
+
+		blocker, ok1 := blockerVal.(string)
+		blocked, ok2 := blockedVal.(string)
+		if !ok1 || !ok2 {
+			continue
+		}
+		edge := model.BlockEdge{BlockerID: blocker, BlockedID: blocked}
+		if since, ok := sinceVal.(time.Time); ok {
+			since = since.UTC()
+			edge.Since = &since
+		}
+		if err := fn(edge); err != nil {
+			return err
+		}
+		n++
+	}
+	return res.Err()
+}
+
+// ImportBlocks idempotentno kreira BLOCKS veze (i krajnje čvorove ako fale).
+// Kao ni Block, ne ostavlja FOLLOWS između blokiranih korisnika: FOLLOWS iz
+// istog snapshot-a ne bi smeo da postoji, a zaostali iz baze se briše.
+func (r *FollowerRepository) ImportBlocks(ctx context.Context, edges []model.BlockEdge) (err error) {
+	ctx, q := r.startQuery(ctx, "ImportBlocks")
+	defer q.end(&err)
+
+	if len(edges) == 0 {
+		return nil
+	}
+	q.batch(len(edges))
+
+	rows := make([]map[string]any, 0, len(edges))
+	for _, e := range edges {
+		var since any
+		if e.Since != nil {
+			since = e.Since.UTC().Format(time.RFC3339Nano)
+		}
+		rows = append(rows, map[string]any{
+			"blockerID": e.BlockerID,
+			"blockedID": e.BlockedID,
+			"since":     since,
+		})
+	}
+
+	ses := r.session(ctx, neo4j.AccessModeWrite)
+	defer ses.Close(ctx)
+
+	_, err = ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			UNWIND $rows AS row
+			MERGE (a:User {id: row.blockerID})
+			MERGE (b:User {id: row.blockedID})
+			MERGE (a)-[r:BLOCKS]->(b)
+			ON CREATE SET r.since = CASE WHEN row.since IS NULL THEN datetime() ELSE datetime(row.since) END
+			WITH a, b
+			OPTIONAL MATCH (a)-[f:FOLLOWS]-(b)
+			DELETE f
+		`, map[string]any{"rows": rows})
+		if err != nil {
+			return nil, err
+		}
+		_, err = res.Consume(ctx)
+		return nil, err
+	})
+	return err
+}
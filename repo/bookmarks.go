@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Bookmarks daju read-your-writes u klasteru: klijent posle write RPC-a dobija
+// bookmark i šalje ga uz sledeće čitanje, pa sesija čeka da replika vidi taj upis.
+
+type bookmarksKey struct{}
+
+// BookmarkTracker nosi bookmarke koje je klijent poslao i pamti poslednji
+// bookmark write sesija otvorenih tokom istog zahteva.
+type BookmarkTracker struct {
+	mu   sync.Mutex
+	in   neo4j.Bookmarks
+	last neo4j.Bookmarks
+}
+
+// WithBookmarks vezuje tracker za ctx; sve sesije repo-a otvorene sa tim ctx-om
+// počinju od datih bookmark-a (prazno = bez čekanja).
+func WithBookmarks(ctx context.Context, bookmarks []string) (context.Context, *BookmarkTracker) {
+	t := &BookmarkTracker{in: neo4j.BookmarksFromRawValues(bookmarks...)}
+	return context.WithValue(ctx, bookmarksKey{}, t), t
+}
+
+// HasBookmarks je true kad je klijent tražio kauzalno čitanje (servis tada
+// preskače keš, koji ne zna za bookmarke).
+func HasBookmarks(ctx context.Context) bool {
+	t := bookmarkTracker(ctx)
+	return t != nil && len(t.in) > 0
+}
+
+// Last vraća bookmark poslednje write sesije; prazno ako zahtev nije ništa upisao.
+func (t *BookmarkTracker) Last() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return neo4j.BookmarksToRawValues(t.last)
+}
+
+// start: ulazni bookmarki plus upisi iz istog zahteva, da i druga sesija vidi prvu.
+func (t *BookmarkTracker) start() neo4j.Bookmarks {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return neo4j.CombineBookmarks(t.in, t.last)
+}
+
+func (t *BookmarkTracker) record(b neo4j.Bookmarks) {
+	if len(b) == 0 {
+		return
+	}
+	t.mu.Lock()
+	t.last = b
+	t.mu.Unlock()
+}
+
+func bookmarkTracker(ctx context.Context) *BookmarkTracker {
+	t, _ := ctx.Value(bookmarksKey{}).(*BookmarkTracker)
+	return t
+}
@@ -1,5 +1,12 @@
 package repo
 
-import "errors"
+import "database-example/apperr"
 
-var ErrUserNotFound = errors.New("follower or followee not found")
+// Domenske greške repo sloja; Reason je deo API-ja (google.rpc.ErrorInfo.reason).
+var (
+	ErrUserNotFound = apperr.New(apperr.KindNotFound, "USER_NOT_FOUND", "follower or followee not found")
+	ErrBlocked      = apperr.New(apperr.KindFailedPrecondition, "BLOCKED", "one of the users has blocked the other")
+	ErrNotBlocked   = apperr.New(apperr.KindNotFound, "NOT_BLOCKED", "block relationship does not exist")
+	ErrNotFollowing = apperr.New(apperr.KindNotFound, "NOT_FOLLOWING", "follow relationship does not exist")
+	ErrNotReady     = apperr.New(apperr.KindUnavailable, "DATABASE_NOT_READY", "database connection is not ready yet")
+)
@@ -0,0 +1,107 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"database-example/apperr"
+	"database-example/logging"
+	"database-example/metrics"
+	"database-example/tracing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// query prati jedan imenovani Cypher upit: span, Prometheus metrike i log.
+// Poziva se na početku repo metode (err je imenovana povratna vrednost):
+//
+//	ctx, q := r.startQuery(ctx, "Follow")
+//	defer q.end(&err)
+type query struct {
+	ctx    context.Context
+	logger *slog.Logger
+	method string
+	start  time.Time
+	span   trace.Span
+}
+
+func (r *FollowerRepository) startQuery(ctx context.Context, method string) (context.Context, *query) {
+	ctx, span := tracing.Tracer().Start(ctx, "neo4j."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "neo4j"),
+			attribute.String("db.namespace", r.database),
+			attribute.String("db.query.name", method),
+		),
+	)
+	return ctx, &query{
+		ctx:    ctx,
+		logger: r.logger,
+		method: method,
+		start:  time.Now(),
+		span:   span,
+	}
+}
+
+// rows beleži broj vraćenih redova na span-u.
+func (q *query) rows(n int) {
+	q.span.SetAttributes(attribute.Int("db.response.returned_rows", n))
+}
+
+// batch beleži broj redova poslatih u UNWIND (import).
+func (q *query) batch(n int) {
+	q.span.SetAttributes(attribute.Int("db.operation.batch.size", n))
+}
+
+// end: domenske greške (nije pronađen, ne prati...) nisu greške baze i ne broje se.
+func (q *query) end(errp *error) {
+	err := *errp
+	if isDomainErr(err) {
+		q.span.SetAttributes(attribute.String("db.result", err.Error()))
+		err = nil
+	}
+	metrics.ObserveQuery(q.method, q.start, err)
+
+	logger := logging.FromContext(q.ctx, q.logger)
+	duration := time.Since(q.start)
+	if err != nil {
+		logger.ErrorContext(q.ctx, "neo4j query failed", "method", q.method, "duration", duration, "error", err)
+	} else {
+		logger.DebugContext(q.ctx, "neo4j query", "method", q.method, "duration", duration)
+	}
+	tracing.End(q.span, err)
+}
+
+// isDomainErr: očekivani ishod upita (nema korisnika, blokada...), ne kvar baze.
+func isDomainErr(err error) bool {
+	e, ok := apperr.As(err)
+	return ok && e.Kind != apperr.KindInternal && e.Kind != apperr.KindUnavailable
+}
+
+// trackedSession broji otvorene sesije; drajver ne izlaže statistiku pool-a,
+// a svaka aktivna sesija drži konekciju iz pool-a.
+type trackedSession struct {
+	neo4j.SessionWithContext
+	closed bool
+	// bookmarks: samo za write sesije zahteva sa BookmarkTracker-om
+	bookmarks *BookmarkTracker
+}
+
+func newTrackedSession(s neo4j.SessionWithContext, bookmarks *BookmarkTracker) *trackedSession {
+	metrics.Neo4jSessionsInUse.Inc()
+	return &trackedSession{SessionWithContext: s, bookmarks: bookmarks}
+}
+
+func (s *trackedSession) Close(ctx context.Context) error {
+	if !s.closed {
+		s.closed = true
+		metrics.Neo4jSessionsInUse.Dec()
+		if s.bookmarks != nil {
+			s.bookmarks.record(s.LastBookmarks())
+		}
+	}
+	return s.SessionWithContext.Close(ctx)
+}
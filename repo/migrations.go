@@ -0,0 +1,258 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// migration je jedan verzionisani korak šeme. Naredbe moraju biti idempotentne
+// (IF NOT EXISTS), jer se korak može ponoviti ako upis verzije ne uspe.
+type migration struct {
+	version int64
+	name    string
+	// check se izvršava pre naredbi; greška zaustavlja migraciju i treba da kaže
+	// operateru šta da popravi u podacima
+	check      func(ctx context.Context, s migrationStore) error
+	statements []string
+}
+
+// migrationStore je deo FollowerRepository-ja koji runner koristi; izdvojen je
+// da bi se redosled, provere i beleženje verzija mogli testirati bez Neo4j-a.
+type migrationStore interface {
+	appliedMigrations(ctx context.Context) (map[int64]bool, error)
+	runSchemaStatement(ctx context.Context, stmt string) error
+	recordMigration(ctx context.Context, m migration) error
+	duplicateUserIDs(ctx context.Context) (duplicateIDs, error)
+}
+
+// Nove migracije se dodaju isključivo na kraj, sa sledećim brojem verzije.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "user_id_unique",
+		// constraint ne može da se napravi dok postoje duplikati
+		check: checkDuplicateUserIDs,
+		statements: []string{
+			// constraint pravi i index na User.id, pa MATCH (:User {id}) više nije label scan
+			`CREATE CONSTRAINT user_id_unique IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE`,
+		},
+	},
+	{
+		version: 2,
+		name:    "schema_migration_version_unique",
+		statements: []string{
+			`CREATE CONSTRAINT schema_migration_version_unique IF NOT EXISTS FOR (m:SchemaMigration) REQUIRE m.version IS UNIQUE`,
+		},
+	},
+	{
+		version: 3,
+		name:    "follows_since_index",
+		statements: []string{
+			`CREATE INDEX follows_since IF NOT EXISTS FOR ()-[r:FOLLOWS]-() ON (r.since)`,
+		},
+	},
+	{
+		version: 4,
+		name:    "user_recommendations_computed_at_index",
+		statements: []string{
+			// materializer traži korisnike sa zastarelim preporukama
+			`CREATE INDEX user_recommendations_computed_at IF NOT EXISTS FOR (u:User) ON (u.recommendationsComputedAt)`,
+		},
+	},
+	{
+		version: 5,
+		name:    "user_last_follow_activity_index",
+		statements: []string{
+			// StaleRecommendationUsers kreće od aktivnih korisnika (range seek), ne od svih User čvorova
+			`CREATE INDEX user_last_follow_activity_at IF NOT EXISTS FOR (u:User) ON (u.lastFollowActivityAt)`,
+			// popunjava oznaku iz postojeće FOLLOW_HISTORY; ponovljen korak preskače već popunjene
+			`MATCH (u:User)
+			WHERE u.lastFollowActivityAt IS NULL AND EXISTS { MATCH (u)-[:FOLLOW_HISTORY]->(:User) }
+			CALL {
+				WITH u
+				MATCH (u)-[h:FOLLOW_HISTORY]->(:User)
+				UNWIND coalesce(h.follows, []) + coalesce(h.unfollows, []) AS t
+				WITH u, max(t) AS last
+				SET u.lastFollowActivityAt = last
+			} IN TRANSACTIONS OF 10000 ROWS`,
+		},
+	},
+}
+
+// Migrate primenjuje sve migracije koje još nisu zabeležene kao (:SchemaMigration)
+// čvorovi u grafu i vraća verzije koje su primenjene u ovom pozivu.
+func (r *FollowerRepository) Migrate(ctx context.Context) ([]int64, error) {
+	return runMigrations(ctx, r, migrations, r.logger)
+}
+
+func runMigrations(ctx context.Context, s migrationStore, list []migration, logger *slog.Logger) ([]int64, error) {
+	done, err := s.appliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read applied migrations: %w", err)
+	}
+
+	applied := make([]int64, 0)
+	for _, m := range list {
+		if done[m.version] {
+			continue
+		}
+		if m.check != nil {
+			if err := m.check(ctx, s); err != nil {
+				return applied, fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+			}
+		}
+		// šema i podaci ne smeju u istu transakciju, pa svaka naredba ide zasebno
+		for _, stmt := range m.statements {
+			if err := s.runSchemaStatement(ctx, stmt); err != nil {
+				return applied, fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+			}
+		}
+		if err := s.recordMigration(ctx, m); err != nil {
+			return applied, fmt.Errorf("record migration %d (%s): %w", m.version, m.name, err)
+		}
+		logger.Info("applied schema migration", "version", m.version, "name", m.name)
+		applied = append(applied, m.version)
+	}
+	return applied, nil
+}
+
+// maxReportedDuplicates: koliko duplih ID-jeva se navodi u grešci
+const maxReportedDuplicates = 20
+
+// checkDuplicateUserIDs odbija migraciju ako više User čvorova ima isti id.
+// Duplikati se ne spajaju automatski: na njih mogu da vise FOLLOWS, BLOCKS,
+// FOLLOW_HISTORY i RECOMMENDED veze, pa odluku koji čvor ostaje donosi operater.
+func checkDuplicateUserIDs(ctx context.Context, s migrationStore) error {
+	dup, err := s.duplicateUserIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("check duplicate User.id: %w", err)
+	}
+	return dup.err()
+}
+
+// duplicateUserIDs vraća broj duplih User.id vrednosti i prvih maxReportedDuplicates.
+func (r *FollowerRepository) duplicateUserIDs(ctx context.Context) (duplicateIDs, error) {
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	resAny, err := ses.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (u:User)
+			WHERE u.id IS NOT NULL
+			WITH u.id AS id, count(*) AS n
+			WHERE n > 1
+			WITH id ORDER BY id
+			WITH collect(id) AS ids
+			RETURN size(ids) AS total, ids[0..$limit] AS sample
+		`, map[string]any{"limit": maxReportedDuplicates})
+		if err != nil {
+			return nil, err
+		}
+		rec, err := res.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+		total, _ := rec.Get("total")
+		sample, _ := rec.Get("sample")
+		n, _ := total.(int64)
+		ids := make([]string, 0, min(n, maxReportedDuplicates))
+		if list, ok := sample.([]any); ok {
+			for _, v := range list {
+				if id, ok := v.(string); ok {
+					ids = append(ids, strconv.Quote(id))
+				}
+			}
+		}
+		return duplicateIDs{total: n, sample: ids}, nil
+	})
+	if err != nil {
+		return duplicateIDs{}, err
+	}
+	return resAny.(duplicateIDs), nil
+}
+
+type duplicateIDs struct {
+	total int64
+	// sample: ID-jevi pod navodnicima (strconv.Quote), sortirani
+	sample []string
+}
+
+// err opisuje duplikate operateru; nil ako ih nema.
+func (dup duplicateIDs) err() error {
+	if dup.total == 0 {
+		return nil
+	}
+	more := ""
+	if dup.total > int64(len(dup.sample)) {
+		more = fmt.Sprintf(" and %d more", dup.total-int64(len(dup.sample)))
+	}
+	return fmt.Errorf("%d User.id values belong to more than one node: %s%s; "+
+		"merge or delete the extra User nodes so each id is unique, then restart "+
+		"(list them with: MATCH (u:User) WITH u.id AS id, count(*) AS n WHERE n > 1 RETURN id, n)",
+		dup.total, strings.Join(dup.sample, ", "), more)
+}
+
+func (r *FollowerRepository) appliedMigrations(ctx context.Context) (map[int64]bool, error) {
+	ses := r.session(ctx, neo4j.AccessModeRead)
+	defer ses.Close(ctx)
+
+	resAny, err := ses.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (m:SchemaMigration)
+			RETURN m.version AS version
+		`, nil)
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[int64]bool)
+		for res.Next(ctx) {
+			v, _ := res.Record().Get("version")
+			if n, ok := v.(int64); ok {
+				out[n] = true
+			}
+		}
+		return out, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resAny.(map[int64]bool), nil
+}
+
+func (r *FollowerRepository) runSchemaStatement(ctx context.Context, stmt string) error {
+	ses := r.session(ctx, neo4j.AccessModeWrite)
+	defer ses.Close(ctx)
+
+	res, err := ses.Run(ctx, stmt, nil)
+	if err != nil {
+		return err
+	}
+	_, err = res.Consume(ctx)
+	return err
+}
+
+func (r *FollowerRepository) recordMigration(ctx context.Context, m migration) error {
+	ses := r.session(ctx, neo4j.AccessModeWrite)
+	defer ses.Close(ctx)
+
+	_, err := ses.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MERGE (m:SchemaMigration {version: $version})
+			ON CREATE SET m.name = $name, m.appliedAt = datetime()
+		`, map[string]any{
+			"version": m.version,
+			"name":    m.name,
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = res.Consume(ctx)
+		return nil, err
+	})
+	return err
+}
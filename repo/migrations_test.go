@@ -0,0 +1,197 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeMigrationStore beleži naredbe i upisane verzije umesto da ih šalje Neo4j-u.
+type fakeMigrationStore struct {
+	applied  map[int64]bool
+	dup      duplicateIDs
+	failStmt string
+	failRead error
+
+	ran      []string
+	recorded []int64
+}
+
+func (s *fakeMigrationStore) appliedMigrations(context.Context) (map[int64]bool, error) {
+	if s.failRead != nil {
+		return nil, s.failRead
+	}
+	out := make(map[int64]bool, len(s.applied))
+	for v := range s.applied {
+		out[v] = true
+	}
+	return out, nil
+}
+
+func (s *fakeMigrationStore) runSchemaStatement(_ context.Context, stmt string) error {
+	if stmt == s.failStmt {
+		return errors.New("schema error")
+	}
+	s.ran = append(s.ran, stmt)
+	return nil
+}
+
+func (s *fakeMigrationStore) recordMigration(_ context.Context, m migration) error {
+	if s.applied == nil {
+		s.applied = make(map[int64]bool)
+	}
+	s.applied[m.version] = true
+	s.recorded = append(s.recorded, m.version)
+	return nil
+}
+
+func (s *fakeMigrationStore) duplicateUserIDs(context.Context) (duplicateIDs, error) {
+	return s.dup, nil
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+var testMigrations = []migration{
+	{version: 1, name: "one", check: checkDuplicateUserIDs, statements: []string{"s1"}},
+	{version: 2, name: "two", statements: []string{"s2a", "s2b"}},
+	{version: 3, name: "three", statements: []string{"s3"}},
+}
+
+func TestMigrationsOrdered(t *testing.T) {
+	seen := make(map[string]bool)
+	for i, m := range migrations {
+		if m.version != int64(i+1) {
+			t.Errorf("migration %q has version %d, want %d", m.name, m.version, i+1)
+		}
+		if seen[m.name] {
+			t.Errorf("duplicate migration name %q", m.name)
+		}
+		seen[m.name] = true
+		if len(m.statements) == 0 {
+			t.Errorf("migration %d has no statements", m.version)
+		}
+	}
+}
+
+func TestRunMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied map[int64]bool
+		want    []int64
+		wantRan []string
+	}{
+		{"fresh", nil, []int64{1, 2, 3}, []string{"s1", "s2a", "s2b", "s3"}},
+		{"partially applied", map[int64]bool{1: true}, []int64{2, 3}, []string{"s2a", "s2b", "s3"}},
+		{"gap is filled", map[int64]bool{1: true, 3: true}, []int64{2}, []string{"s2a", "s2b"}},
+		{"up to date", map[int64]bool{1: true, 2: true, 3: true}, []int64{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fakeMigrationStore{applied: tt.applied}
+			got, err := runMigrations(context.Background(), s, testMigrations, discardLogger())
+			if err != nil {
+				t.Fatalf("runMigrations: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applied = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(s.recorded, nilIfEmpty(tt.want)) {
+				t.Errorf("recorded = %v, want %v", s.recorded, tt.want)
+			}
+			if !reflect.DeepEqual(s.ran, tt.wantRan) {
+				t.Errorf("ran = %v, want %v", s.ran, tt.wantRan)
+			}
+
+			// drugi prolaz nema šta da radi
+			again, err := runMigrations(context.Background(), s, testMigrations, discardLogger())
+			if err != nil || len(again) != 0 {
+				t.Errorf("second run = %v, %v; want nothing applied", again, err)
+			}
+		})
+	}
+}
+
+func nilIfEmpty(v []int64) []int64 {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+func TestRunMigrationsCheckFails(t *testing.T) {
+	s := &fakeMigrationStore{dup: duplicateIDs{total: 1, sample: []string{`"alice"`}}}
+	got, err := runMigrations(context.Background(), s, testMigrations, discardLogger())
+	if err == nil || !strings.Contains(err.Error(), `migration 1 (one)`) || !strings.Contains(err.Error(), `"alice"`) {
+		t.Fatalf("err = %v, want duplicate error for migration 1", err)
+	}
+	if len(got) != 0 || len(s.ran) != 0 || len(s.recorded) != 0 {
+		t.Errorf("applied=%v ran=%v recorded=%v; check must stop before any statement", got, s.ran, s.recorded)
+	}
+}
+
+func TestRunMigrationsStatementFails(t *testing.T) {
+	s := &fakeMigrationStore{failStmt: "s2b"}
+	got, err := runMigrations(context.Background(), s, testMigrations, discardLogger())
+	if err == nil || !strings.Contains(err.Error(), "migration 2 (two)") {
+		t.Fatalf("err = %v, want failure in migration 2", err)
+	}
+	// verzija 2 nije zabeležena, pa se ceo korak ponavlja pri sledećem startu
+	if !reflect.DeepEqual(got, []int64{1}) || !reflect.DeepEqual(s.recorded, []int64{1}) {
+		t.Errorf("applied=%v recorded=%v, want only [1]", got, s.recorded)
+	}
+
+	s.failStmt = ""
+	s.ran = nil
+	got, err = runMigrations(context.Background(), s, testMigrations, discardLogger())
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int64{2, 3}) || !reflect.DeepEqual(s.ran, []string{"s2a", "s2b", "s3"}) {
+		t.Errorf("retry applied=%v ran=%v", got, s.ran)
+	}
+}
+
+func TestRunMigrationsReadFails(t *testing.T) {
+	s := &fakeMigrationStore{failRead: errors.New("unavailable")}
+	if _, err := runMigrations(context.Background(), s, testMigrations, discardLogger()); err == nil {
+		t.Fatal("expected error when applied migrations cannot be read")
+	}
+	if len(s.ran) != 0 {
+		t.Errorf("ran = %v, want nothing", s.ran)
+	}
+}
+
+func TestDuplicateIDsErr(t *testing.T) {
+	tests := []struct {
+		name string
+		dup  duplicateIDs
+		want string // "" znači bez greške
+	}{
+		{"none", duplicateIDs{}, ""},
+		{"all listed", duplicateIDs{total: 2, sample: []string{`"alice"`, `"bob"`}}, `2 User.id values belong to more than one node: "alice", "bob"; merge`},
+		{"truncated", duplicateIDs{total: 25, sample: []string{`"a"`, `"b"`}}, `25 User.id values belong to more than one node: "a", "b" and 23 more; merge`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dup.err()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), "MATCH (u:User)") {
+				t.Errorf("err = %v, want the query that lists duplicates", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"database-example/config"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
+)
+
+// driverTLS prevodi Neo4jTLSConfig u URI i podešavanje drajvera. Drajver o
+// enkripciji odlučuje po šemi URI-ja, pa trust "all" menja +s u +ssc.
+// Config.validate već proverava da je šema kompatibilna.
+func driverTLS(uri string, cfg config.Neo4jTLSConfig) (string, func(*neo4j.Config), error) {
+	var (
+		tlsConfig *tls.Config
+		provider  auth.ClientCertificateProvider
+	)
+	switch cfg.Trust {
+	case "all":
+		if scheme, rest, ok := strings.Cut(uri, "://"); ok && strings.HasSuffix(scheme, "+s") {
+			uri = scheme + "sc://" + rest
+		}
+	case "ca":
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return "", nil, fmt.Errorf("read neo4j CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return "", nil, errors.New("neo4j CA file contains no PEM certificates")
+		}
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}
+	}
+
+	if cfg.ClientCertFile != "" {
+		static, err := auth.NewStaticClientCertificateProvider(auth.ClientCertificate{
+			CertFile: cfg.ClientCertFile,
+			KeyFile:  cfg.ClientKeyFile,
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("load neo4j client certificate: %w", err)
+		}
+		provider = static
+	}
+	return uri, func(c *neo4j.Config) {
+		c.TlsConfig = tlsConfig
+		c.ClientCertificateProvider = provider
+	}, nil
+}
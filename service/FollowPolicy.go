@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"database-example/metrics"
+	"database-example/model"
+	"database-example/repo"
+	"database-example/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// followCheck je follow politika koju repo.Follow proverava u istoj transakciji
+// u kojoj upisuje praćenje; nil kad su sva pravila isključena.
+func (s *FollowerService) followCheck() *repo.FollowCheck {
+	p := s.Policy
+	if p.MaxFollowees == 0 && p.DailyFollowQuota == 0 && p.ChurnMaxChanges == 0 {
+		return nil
+	}
+	return &repo.FollowCheck{ChurnWindow: p.ChurnWindow, Allow: s.checkFollowPolicy}
+}
+
+// checkFollowPolicy: ako user već prati target-a, Follow ništa ne menja pa se
+// pravila ne primenjuju.
+func (s *FollowerService) checkFollowPolicy(activity model.FollowActivity) error {
+	if activity.Following {
+		return nil
+	}
+
+	p := s.Policy
+	var (
+		rule string
+		err  error
+	)
+	switch {
+	case p.MaxFollowees > 0 && activity.Followees >= int64(p.MaxFollowees):
+		rule, err = "max_followees", ErrFolloweeLimit.WithMetadata("max_followees", strconv.Itoa(p.MaxFollowees))
+	case p.DailyFollowQuota > 0 && activity.FollowsLastDay >= int64(p.DailyFollowQuota):
+		rule, err = "daily_quota", ErrDailyFollowQuota.WithMetadata("daily_follow_quota", strconv.Itoa(p.DailyFollowQuota))
+	case p.ChurnMaxChanges > 0 && activity.RecentChanges >= int64(p.ChurnMaxChanges):
+		rule, err = "churn", ErrFollowChurn.WithMetadata("churn_window", p.ChurnWindow.String())
+	default:
+		return nil
+	}
+	metrics.FollowPolicyRejections.WithLabelValues(rule).Inc()
+	return err
+}
+
+// GetFollowPolicy vraća limite i koliko je korisniku ostalo (za prikaz u UI-ju).
+func (s *FollowerService) GetFollowPolicy(ctx context.Context, userID string) (_ model.FollowPolicy, err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.GetFollowPolicy", attribute.String("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	activity, err := s.FollowerRepo.GetFollowActivity(ctx, userID, "", s.Policy.ChurnWindow)
+	if err != nil {
+		return model.FollowPolicy{}, err
+	}
+
+	p := s.Policy
+	return model.FollowPolicy{
+		UserID:                userID,
+		MaxFollowees:          int64(p.MaxFollowees),
+		Followees:             activity.Followees,
+		RemainingFollowees:    remaining(p.MaxFollowees, activity.Followees),
+		DailyFollowQuota:      int64(p.DailyFollowQuota),
+		FollowsLastDay:        activity.FollowsLastDay,
+		RemainingDailyFollows: remaining(p.DailyFollowQuota, activity.FollowsLastDay),
+		ChurnWindow:           p.ChurnWindow,
+		ChurnMaxChanges:       int64(p.ChurnMaxChanges),
+	}, nil
+}
+
+// remaining: -1 kad je limit isključen (0), inače nikad manje od 0.
+func remaining(limit int, used int64) int64 {
+	if limit == 0 {
+		return -1
+	}
+	return max(int64(limit)-used, 0)
+}
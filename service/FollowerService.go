@@ -3,54 +3,144 @@ package service
 
 import (
 	"context"
+	"database-example/cache"
+	"database-example/config"
 	"database-example/model"
 	"database-example/repo"
-	"errors"
-	"strings"
+	"database-example/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type FollowerService struct {
 	FollowerRepo *repo.FollowerRepository
+	// Policy: limiti praćenja (vidi checkFollowPolicy); nulta vrednost = bez limita
+	Policy config.FollowPolicyConfig
+	// Cache za followee liste, brojeve i preporuke; nil = bez keša
+	Cache cache.Cache
+	// Recs podešava precomputed preporuke; RecsQueue nil = preporuke se uvek računaju uživo
+	Recs      config.RecommendationsConfig
+	RecsQueue RecommendationQueue
+	// Pages: podrazumevana i najveća veličina strane; veći limit se smanjuje
+	// (odbijanje, ako je podešeno, radi validation interceptor)
+	Pages config.PaginationConfig
 }
 
-var ErrInvalidIDs = errors.New("followerID and followeeID must be non-empty and different")
+// RecommendationQueue prima korisnike čije preporuke treba ponovo izračunati
+// (recommendations.Materializer).
+type RecommendationQueue interface {
+	Enqueue(userIDs ...string)
+}
 
-func (s *FollowerService) Follow(followerID, followeeID string) error {
-	// biznis validacija u servis sloju
-	followerID = strings.TrimSpace(followerID)
-	followeeID = strings.TrimSpace(followeeID)
-	if followerID == "" || followeeID == "" || followerID == followeeID {
-		return ErrInvalidIDs
-	}
+// Ping proverava da li je baza spremna (repo.ErrNotReady dok traje startup).
+func (s *FollowerService) Ping(ctx context.Context) error {
+	return s.FollowerRepo.Health(ctx)
+}
 
-	// kreiraj kontekst (isti stil kao u tvom UserService-u)
-	ctx := context.Background()
-	return s.FollowerRepo.Follow(ctx, followerID, followeeID)
+// Follow prima ctx od handler-a da bi span i Neo4j upit bili u istom trace-u.
+func (s *FollowerService) Follow(ctx context.Context, followerID, followeeID string) (err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.Follow", pairAttrs("follower.id", followerID, "followee.id", followeeID)...)
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.FollowerRepo.Follow(ctx, followerID, followeeID, s.followCheck()); err != nil {
+		return err
+	}
+	s.followChanged(ctx, followerID, followeeID)
+	return nil
 }
 
-func (s *FollowerService) Unfollow(ctx context.Context, followerID, followeeID string) error {
-	if followerID == "" || followeeID == "" {
-		return errors.New("missing ids")
+func (s *FollowerService) Unfollow(ctx context.Context, followerID, followeeID string) (err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.Unfollow", pairAttrs("follower.id", followerID, "followee.id", followeeID)...)
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.FollowerRepo.Unfollow(ctx, followerID, followeeID); err != nil {
+		return err
 	}
-	if followerID == followeeID {
-		return errors.New("cannot unfollow self")
+	s.followChanged(ctx, followerID, followeeID)
+	return nil
+}
+
+func (s *FollowerService) GetRecommendations(ctx context.Context, userID string, limit int) (_ model.RecommendationSet, err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.GetRecommendations", attribute.String("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	limit = s.pageSize(limit, defaultRecommendationsLimit)
+	return cached(ctx, s.cache(), "recommendations", pageKey("recommendations", userID, 0, limit), []string{recsTag(userID)},
+		func() (model.RecommendationSet, error) {
+			return s.loadRecommendations(ctx, userID, limit)
+		})
+}
+
+func (s *FollowerService) GetFollowees(ctx context.Context, userID string, skip, limit int) (_ model.UserPage, err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.GetFollowees", attribute.String("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	limit = s.pageSize(limit, s.Pages.DefaultPageSize)
+	return cached(ctx, s.cache(), "followees", pageKey("followees", userID, skip, limit), []string{followeesTag(userID)},
+		func() (model.UserPage, error) {
+			return s.FollowerRepo.GetFollowees(ctx, userID, skip, limit)
+		})
+}
+
+func (s *FollowerService) GetFollowers(ctx context.Context, userID string, skip, limit int) (_ model.UserPage, err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.GetFollowers", attribute.String("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	limit = s.pageSize(limit, s.Pages.DefaultPageSize)
+	return s.FollowerRepo.GetFollowers(ctx, userID, skip, limit)
+}
+
+func (s *FollowerService) GetFollowCounts(ctx context.Context, userID string) (_ model.FollowCounts, err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.GetFollowCounts", attribute.String("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	return cached(ctx, s.cache(), "counts", "counts:"+userID, []string{countsTag(userID)},
+		func() (model.FollowCounts, error) {
+			return s.FollowerRepo.GetFollowCounts(ctx, userID)
+		})
+}
+
+// Block briše praćenje u oba smera i sprečava novo dok blokada traje.
+func (s *FollowerService) Block(ctx context.Context, blockerID, blockedID string) (err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.Block", pairAttrs("blocker.id", blockerID, "blocked.id", blockedID)...)
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.FollowerRepo.Block(ctx, blockerID, blockedID); err != nil {
+		return err
 	}
-	return s.FollowerRepo.Unfollow(ctx, followerID, followeeID)
+	// blokada briše praćenje u oba smera i isključuje preporuke
+	s.followChanged(ctx, blockerID, blockedID)
+	s.followChanged(ctx, blockedID, blockerID)
+	return nil
 }
 
-func (s *FollowerService) GetRecommendations(ctx context.Context, userID string, limit int) ([]model.Recommendation, error) {
-	if userID == "" {
-		return nil, errors.New("missing user_id")
+func (s *FollowerService) Unblock(ctx context.Context, blockerID, blockedID string) (err error) {
+	ctx, span := tracing.Start(ctx, "FollowerService.Unblock", pairAttrs("blocker.id", blockerID, "blocked.id", blockedID)...)
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.FollowerRepo.Unblock(ctx, blockerID, blockedID); err != nil {
+		return err
 	}
+	s.cache().Invalidate(ctx, recsTag(blockerID), recsTag(blockedID))
+	s.enqueueRecs(blockerID, blockedID)
+	return nil
+}
+
+// preporuke imaju manju podrazumevanu stranu od listi
+const defaultRecommendationsLimit = 10
+
+// pageSize: limit <= 0 znači def, a veći od Pages.MaxPageSize se smanjuje.
+// Nulta Pages konfiguracija ne ograničava (repo ima svoje podrazumevane vrednosti).
+func (s *FollowerService) pageSize(limit, def int) int {
 	if limit <= 0 {
-		limit = 10
+		limit = def
+	}
+	if s.Pages.MaxPageSize > 0 && limit > s.Pages.MaxPageSize {
+		limit = s.Pages.MaxPageSize
 	}
-	return s.FollowerRepo.GetRecommendations(ctx, userID, limit)
+	return limit
 }
 
-func (s *FollowerService) GetFollowees(ctx context.Context, userID string, skip, limit int) ([]string, error) {
-	if userID == "" {
-		return nil, errors.New("missing user_id")
-	}
-	return s.FollowerRepo.GetFollowees(ctx, userID, skip, limit)
+func pairAttrs(k1, v1, k2, v2 string) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String(k1, v1), attribute.String(k2, v2)}
 }
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"database-example/cache"
+	"database-example/metrics"
+	"database-example/repo"
+)
+
+// Tagovi keša po korisniku; Follow/Unfollow/Block invalidiraju samo pogođene korisnike.
+func followeesTag(userID string) string { return "followees:" + userID }
+func countsTag(userID string) string    { return "counts:" + userID }
+func recsTag(userID string) string      { return "recommendations:" + userID }
+
+func (s *FollowerService) cache() cache.Cache {
+	if s.Cache == nil {
+		return cache.Noop{}
+	}
+	return s.Cache
+}
+
+// cached je read-through: vraća vrednost iz keša ili je učitava i upisuje.
+// Ako se invalidacija desi dok load traje, keš može držati staru vrednost
+// najduže do isteka TTL-a. Zahtev sa bookmark-om uvek čita iz baze, jer keš
+// (lokalan za instancu) možda nije video upis sa druge instance.
+func cached[T any](ctx context.Context, c cache.Cache, kind, key string, tags []string, load func() (T, error)) (T, error) {
+	if repo.HasBookmarks(ctx) {
+		metrics.CacheRequests.WithLabelValues(kind, "bypass").Inc()
+	} else {
+		if v, ok := c.Get(ctx, key); ok {
+			if t, ok := v.(T); ok {
+				metrics.CacheRequests.WithLabelValues(kind, "hit").Inc()
+				return t, nil
+			}
+		}
+		metrics.CacheRequests.WithLabelValues(kind, "miss").Inc()
+	}
+
+	v, err := load()
+	if err != nil {
+		return v, err
+	}
+	c.Set(ctx, key, v, tags...)
+	return v, nil
+}
+
+// followChanged: promena veze a->b menja followee listu, brojeve i preporuke za a,
+// a brojeve (followers) za b. Preporuke za a se i ponovo materializuju.
+func (s *FollowerService) followChanged(ctx context.Context, followerID, followeeID string) {
+	s.cache().Invalidate(ctx,
+		followeesTag(followerID),
+		countsTag(followerID),
+		recsTag(followerID),
+		countsTag(followeeID),
+	)
+	s.enqueueRecs(followerID)
+}
+
+// InvalidateRecommendations briše keširane preporuke (posle novog precomputed skupa).
+func (s *FollowerService) InvalidateRecommendations(ctx context.Context, userID string) {
+	s.cache().Invalidate(ctx, recsTag(userID))
+}
+
+func pageKey(kind, userID string, skip, limit int) string {
+	return fmt.Sprintf("%s:%s:%d:%d", kind, userID, skip, limit)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"database-example/cache"
+	"database-example/repo"
+)
+
+// recordingCache beleži invalidirane tagove, a čuva vrednosti u LRU-u.
+type recordingCache struct {
+	*cache.LRU
+	invalidated []string
+}
+
+func (c *recordingCache) Invalidate(ctx context.Context, tags ...string) {
+	c.invalidated = append(c.invalidated, tags...)
+	c.LRU.Invalidate(ctx, tags...)
+}
+
+type recordingQueue struct {
+	users []string
+}
+
+func (q *recordingQueue) Enqueue(userIDs ...string) {
+	q.users = append(q.users, userIDs...)
+}
+
+func TestCachedReadThrough(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(10, time.Minute)
+	loads := 0
+	load := func() (int, error) {
+		loads++
+		return 42, nil
+	}
+
+	for range 3 {
+		v, err := cached(ctx, c, "counts", "counts:a", []string{countsTag("a")}, load)
+		if err != nil || v != 42 {
+			t.Fatalf("cached = %v, %v", v, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("load called %d times, want 1", loads)
+	}
+
+	c.Invalidate(ctx, countsTag("a"))
+	if _, err := cached(ctx, c, "counts", "counts:a", []string{countsTag("a")}, load); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 {
+		t.Errorf("load after invalidation called %d times, want 2", loads)
+	}
+}
+
+func TestCachedDoesNotStoreErrors(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(10, time.Minute)
+	boom := errors.New("boom")
+
+	if _, err := cached(ctx, c, "counts", "k", nil, func() (int, error) { return 0, boom }); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want boom", err)
+	}
+	if _, ok := c.Get(ctx, "k"); ok {
+		t.Error("failed load was cached")
+	}
+}
+
+// Vrednost drugog tipa pod istim ključem je promašaj, ne panic.
+func TestCachedTypeMismatchIsMiss(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(10, time.Minute)
+	c.Set(ctx, "k", "string")
+
+	v, err := cached(ctx, c, "counts", "k", nil, func() (int, error) { return 7, nil })
+	if err != nil || v != 7 {
+		t.Fatalf("cached = %v, %v; want 7", v, err)
+	}
+}
+
+func TestCachedBookmarksBypassCache(t *testing.T) {
+	c := cache.NewLRU(10, time.Minute)
+	c.Set(context.Background(), "k", 1)
+
+	ctx, _ := repo.WithBookmarks(context.Background(), []string{"bookmark:1"})
+	v, err := cached(ctx, c, "counts", "k", nil, func() (int, error) { return 2, nil })
+	if err != nil || v != 2 {
+		t.Fatalf("cached with bookmark = %v, %v; want fresh value 2", v, err)
+	}
+	// sveža vrednost iz baze zamenjuje staru u kešu
+	if got, _ := c.Get(context.Background(), "k"); got != 2 {
+		t.Errorf("cache after bypass = %v, want 2", got)
+	}
+
+	// prazan bookmark ne traži kauzalno čitanje
+	ctx, _ = repo.WithBookmarks(context.Background(), nil)
+	v, _ = cached(ctx, c, "counts", "k", nil, func() (int, error) { return 3, nil })
+	if v != 2 {
+		t.Errorf("cached without bookmarks = %v, want cached 2", v)
+	}
+}
+
+func TestFollowChangedInvalidatesTags(t *testing.T) {
+	c := &recordingCache{LRU: cache.NewLRU(10, time.Minute)}
+	q := &recordingQueue{}
+	s := &FollowerService{Cache: c, RecsQueue: q}
+
+	s.followChanged(context.Background(), "alice", "bob")
+
+	got := append([]string(nil), c.invalidated...)
+	sort.Strings(got)
+	want := []string{"counts:alice", "counts:bob", "followees:alice", "recommendations:alice"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("invalidated = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(q.users, []string{"alice"}) {
+		t.Errorf("enqueued = %v, want [alice]", q.users)
+	}
+}
+
+func TestInvalidateRecommendations(t *testing.T) {
+	c := &recordingCache{LRU: cache.NewLRU(10, time.Minute)}
+	s := &FollowerService{Cache: c}
+
+	s.InvalidateRecommendations(context.Background(), "alice")
+	if !reflect.DeepEqual(c.invalidated, []string{"recommendations:alice"}) {
+		t.Errorf("invalidated = %v", c.invalidated)
+	}
+	// bez keša (nil) servis koristi Noop
+	(&FollowerService{}).followChanged(context.Background(), "a", "b")
+}
@@ -0,0 +1,10 @@
+package service
+
+import "database-example/apperr"
+
+// Greške follow politike; limit ide u ErrorInfo.metadata (vidi checkFollowPolicy).
+var (
+	ErrFolloweeLimit    = apperr.New(apperr.KindFailedPrecondition, "FOLLOWEE_LIMIT_REACHED", "maximum number of followees reached")
+	ErrDailyFollowQuota = apperr.New(apperr.KindResourceExhausted, "DAILY_FOLLOW_QUOTA_EXCEEDED", "daily follow quota exceeded")
+	ErrFollowChurn      = apperr.New(apperr.KindResourceExhausted, "FOLLOW_CHURN_DETECTED", "too many follow/unfollow changes for this user, try again later")
+)
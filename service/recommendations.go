@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"database-example/model"
+)
+
+// loadRecommendations služi precomputed skup ako postoji i nije stariji od
+// Recs.MaxAge; inače računa uživo i zakazuje materializaciju za sledeći put.
+func (s *FollowerService) loadRecommendations(ctx context.Context, userID string, limit int) (model.RecommendationSet, error) {
+	if s.RecsQueue != nil && limit <= s.Recs.TopN {
+		set, found, err := s.FollowerRepo.GetPrecomputedRecommendations(ctx, userID, limit)
+		if err == nil && found && time.Since(set.ComputedAt) <= s.Recs.MaxAge {
+			return set, nil
+		}
+		s.RecsQueue.Enqueue(userID)
+	}
+
+	items, err := s.FollowerRepo.GetRecommendations(ctx, userID, limit)
+	if err != nil {
+		return model.RecommendationSet{}, err
+	}
+	return model.RecommendationSet{Items: items, ComputedAt: time.Now().UTC()}, nil
+}
+
+func (s *FollowerService) enqueueRecs(userIDs ...string) {
+	if s.RecsQueue != nil {
+		s.RecsQueue.Enqueue(userIDs...)
+	}
+}
@@ -0,0 +1,206 @@
+package snapshot
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"database-example/model"
+)
+
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+)
+
+// BLOCKS redovi u CSV-u koriste iste kolone: follower_id = blocker, followee_id = blocked.
+var csvHeader = []string{"type", "id", "follower_id", "followee_id", "since"}
+
+// ParseFormat prihvata eksplicitni format, a ako je prazan pogađa po ekstenziji fajla.
+func ParseFormat(name, path string) (Format, error) {
+	if name == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			return FormatCSV, nil
+		default:
+			return FormatJSONL, nil
+		}
+	}
+	switch Format(strings.ToLower(name)) {
+	case FormatJSONL, "json", "ndjson":
+		return FormatJSONL, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("unknown snapshot format %q (expected jsonl or csv)", name)
+}
+
+type recordWriter interface {
+	Write(rec model.SnapshotRecord) error
+	Flush() error
+}
+
+type recordReader interface {
+	// Read vraća io.EOF kada nema više zapisa.
+	Read() (model.SnapshotRecord, error)
+}
+
+func newWriter(f Format, w io.Writer) (recordWriter, error) {
+	switch f {
+	case FormatJSONL:
+		bw := bufio.NewWriter(w)
+		return &jsonlWriter{bw: bw, enc: json.NewEncoder(bw)}, nil
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return nil, err
+		}
+		return &csvWriter{cw: cw}, nil
+	}
+	return nil, fmt.Errorf("unknown snapshot format %q", f)
+}
+
+func newReader(f Format, r io.Reader) (recordReader, error) {
+	switch f {
+	case FormatJSONL:
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		return &jsonlReader{sc: sc}, nil
+	case FormatCSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = len(csvHeader)
+		header, err := cr.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read csv header: %w", err)
+		}
+		if strings.Join(header, ",") != strings.Join(csvHeader, ",") {
+			return nil, fmt.Errorf("unexpected csv header %q", strings.Join(header, ","))
+		}
+		return &csvReader{cr: cr, line: 1}, nil
+	}
+	return nil, fmt.Errorf("unknown snapshot format %q", f)
+}
+
+// --- JSON Lines ---
+
+type jsonlWriter struct {
+	bw  *bufio.Writer
+	enc *json.Encoder
+}
+
+func (w *jsonlWriter) Write(rec model.SnapshotRecord) error { return w.enc.Encode(rec) }
+func (w *jsonlWriter) Flush() error                         { return w.bw.Flush() }
+
+type jsonlReader struct {
+	sc   *bufio.Scanner
+	line int
+}
+
+func (r *jsonlReader) Read() (model.SnapshotRecord, error) {
+	for r.sc.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.sc.Text())
+		if text == "" {
+			continue
+		}
+		var rec model.SnapshotRecord
+		if err := json.Unmarshal([]byte(text), &rec); err != nil {
+			return rec, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		if err := validate(rec); err != nil {
+			return rec, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		return rec, nil
+	}
+	if err := r.sc.Err(); err != nil {
+		return model.SnapshotRecord{}, err
+	}
+	return model.SnapshotRecord{}, io.EOF
+}
+
+// --- CSV ---
+
+type csvWriter struct {
+	cw *csv.Writer
+}
+
+func (w *csvWriter) Write(rec model.SnapshotRecord) error {
+	since := ""
+	if rec.Since != nil {
+		since = rec.Since.UTC().Format(time.RFC3339Nano)
+	}
+	from, to := rec.FollowerID, rec.FolloweeID
+	if rec.Type == model.SnapshotBlocks {
+		from, to = rec.BlockerID, rec.BlockedID
+	}
+	return w.cw.Write([]string{rec.Type, rec.ID, from, to, since})
+}
+
+func (w *csvWriter) Flush() error {
+	w.cw.Flush()
+	return w.cw.Error()
+}
+
+type csvReader struct {
+	cr   *csv.Reader
+	line int
+}
+
+func (r *csvReader) Read() (model.SnapshotRecord, error) {
+	row, err := r.cr.Read()
+	if err != nil {
+		return model.SnapshotRecord{}, err
+	}
+	r.line++
+	rec := model.SnapshotRecord{Type: row[0], ID: row[1]}
+	if rec.Type == model.SnapshotBlocks {
+		rec.BlockerID, rec.BlockedID = row[2], row[3]
+	} else {
+		rec.FollowerID, rec.FolloweeID = row[2], row[3]
+	}
+	if row[4] != "" {
+		since, err := time.Parse(time.RFC3339Nano, row[4])
+		if err != nil {
+			return rec, fmt.Errorf("line %d: invalid since: %w", r.line, err)
+		}
+		rec.Since = &since
+	}
+	if err := validate(rec); err != nil {
+		return rec, fmt.Errorf("line %d: %w", r.line, err)
+	}
+	return rec, nil
+}
+
+func validate(rec model.SnapshotRecord) error {
+	switch rec.Type {
+	case model.SnapshotUser:
+		if strings.TrimSpace(rec.ID) == "" {
+			return errors.New("user record without id")
+		}
+	case model.SnapshotFollows:
+		if strings.TrimSpace(rec.FollowerID) == "" || strings.TrimSpace(rec.FolloweeID) == "" {
+			return errors.New("follows record without follower or followee id")
+		}
+		if rec.FollowerID == rec.FolloweeID {
+			return errors.New("follows record where follower equals followee")
+		}
+	case model.SnapshotBlocks:
+		if strings.TrimSpace(rec.BlockerID) == "" || strings.TrimSpace(rec.BlockedID) == "" {
+			return errors.New("blocks record without blocker or blocked id")
+		}
+		if rec.BlockerID == rec.BlockedID {
+			return errors.New("blocks record where blocker equals blocked")
+		}
+	default:
+		return fmt.Errorf("unknown record type %q", rec.Type)
+	}
+	return nil
+}
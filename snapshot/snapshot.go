@@ -0,0 +1,222 @@
+// Package snapshot izvozi i uvozi follow graf (User čvorove, FOLLOWS i BLOCKS veze)
+// u JSON Lines ili CSV formatu.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"database-example/model"
+)
+
+// Store je podskup repozitorijuma koji snapshot koristi.
+type Store interface {
+	ExportUsers(ctx context.Context, fn func(id string) error) error
+	ExportFollows(ctx context.Context, fn func(model.FollowEdge) error) error
+	ImportUsers(ctx context.Context, ids []string) error
+	// ImportFollows vraća broj preskočenih veza (par koji je blokiran)
+	ImportFollows(ctx context.Context, edges []model.FollowEdge) (skipped int, err error)
+	ExportBlocks(ctx context.Context, fn func(model.BlockEdge) error) error
+	ImportBlocks(ctx context.Context, edges []model.BlockEdge) error
+}
+
+type Stats struct {
+	Users   int
+	Follows int
+	Blocks  int
+	// SkippedFollows: FOLLOWS redovi koje store nije upisao jer je par blokiran
+	SkippedFollows int
+}
+
+const (
+	DefaultBatchSize    = 500
+	exportProgressEvery = 10000
+)
+
+// Export upisuje prvo sve korisnike, pa FOLLOWS, pa BLOCKS veze.
+func Export(ctx context.Context, store Store, w io.Writer, format Format, logger *slog.Logger) (Stats, error) {
+	var stats Stats
+	rw, err := newWriter(format, w)
+	if err != nil {
+		return stats, err
+	}
+
+	err = store.ExportUsers(ctx, func(id string) error {
+		stats.Users++
+		if stats.Users%exportProgressEvery == 0 {
+			logger.Info("export progress", "users", stats.Users)
+		}
+		return rw.Write(model.SnapshotRecord{Type: model.SnapshotUser, ID: id})
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	err = store.ExportFollows(ctx, func(e model.FollowEdge) error {
+		stats.Follows++
+		if stats.Follows%exportProgressEvery == 0 {
+			logger.Info("export progress", "users", stats.Users, "follows", stats.Follows)
+		}
+		return rw.Write(model.SnapshotRecord{
+			Type:       model.SnapshotFollows,
+			FollowerID: e.FollowerID,
+			FolloweeID: e.FolloweeID,
+			Since:      e.Since,
+		})
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	err = store.ExportBlocks(ctx, func(e model.BlockEdge) error {
+		stats.Blocks++
+		if stats.Blocks%exportProgressEvery == 0 {
+			logger.Info("export progress", "users", stats.Users, "follows", stats.Follows, "blocks", stats.Blocks)
+		}
+		return rw.Write(model.SnapshotRecord{
+			Type:      model.SnapshotBlocks,
+			BlockerID: e.BlockerID,
+			BlockedID: e.BlockedID,
+			Since:     e.Since,
+		})
+	})
+	if err != nil {
+		return stats, err
+	}
+	return stats, rw.Flush()
+}
+
+type ImportOptions struct {
+	BatchSize int
+	// DryRun samo parsira i validira ulaz, ništa ne upisuje (store može biti nil).
+	DryRun bool
+}
+
+// Import čita snapshot i upisuje ga u batch-evima. Import je idempotentan
+// (MERGE), pa se prekinut import može bezbedno ponoviti.
+func Import(ctx context.Context, store Store, r io.Reader, format Format, opts ImportOptions, logger *slog.Logger) (Stats, error) {
+	var stats Stats
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if store == nil && !opts.DryRun {
+		return stats, errors.New("snapshot import requires a store")
+	}
+
+	rr, err := newReader(format, r)
+	if err != nil {
+		return stats, err
+	}
+
+	users := make([]string, 0, opts.BatchSize)
+	follows := make([]model.FollowEdge, 0, opts.BatchSize)
+	blocks := make([]model.BlockEdge, 0, opts.BatchSize)
+
+	flushUsers := func() error {
+		if len(users) == 0 {
+			return nil
+		}
+		if !opts.DryRun {
+			if err := store.ImportUsers(ctx, users); err != nil {
+				return err
+			}
+		}
+		stats.Users += len(users)
+		users = users[:0]
+		logger.Info("import progress", "users", stats.Users, "follows", stats.Follows, "blocks", stats.Blocks, "dry_run", opts.DryRun)
+		return nil
+	}
+	flushFollows := func() error {
+		if len(follows) == 0 {
+			return nil
+		}
+		skipped := 0
+		if !opts.DryRun {
+			var err error
+			if skipped, err = store.ImportFollows(ctx, follows); err != nil {
+				return err
+			}
+		}
+		stats.Follows += len(follows) - skipped
+		stats.SkippedFollows += skipped
+		follows = follows[:0]
+		logger.Info("import progress", "users", stats.Users, "follows", stats.Follows, "skipped_follows", stats.SkippedFollows, "blocks", stats.Blocks, "dry_run", opts.DryRun)
+		return nil
+	}
+	flushBlocks := func() error {
+		if len(blocks) == 0 {
+			return nil
+		}
+		if !opts.DryRun {
+			if err := store.ImportBlocks(ctx, blocks); err != nil {
+				return err
+			}
+		}
+		stats.Blocks += len(blocks)
+		blocks = blocks[:0]
+		logger.Info("import progress", "users", stats.Users, "follows", stats.Follows, "blocks", stats.Blocks, "dry_run", opts.DryRun)
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		rec, err := rr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+
+		switch rec.Type {
+		case model.SnapshotUser:
+			users = append(users, rec.ID)
+			if len(users) >= opts.BatchSize {
+				if err := flushUsers(); err != nil {
+					return stats, err
+				}
+			}
+		case model.SnapshotFollows:
+			follows = append(follows, model.FollowEdge{
+				FollowerID: rec.FollowerID,
+				FolloweeID: rec.FolloweeID,
+				Since:      rec.Since,
+			})
+			if len(follows) >= opts.BatchSize {
+				if err := flushFollows(); err != nil {
+					return stats, err
+				}
+			}
+		case model.SnapshotBlocks:
+			// FOLLOWS pre BLOCKS, da ImportBlocks vidi (i obriše) praćenja između blokiranih
+			if err := flushFollows(); err != nil {
+				return stats, err
+			}
+			blocks = append(blocks, model.BlockEdge{
+				BlockerID: rec.BlockerID,
+				BlockedID: rec.BlockedID,
+				Since:     rec.Since,
+			})
+			if len(blocks) >= opts.BatchSize {
+				if err := flushBlocks(); err != nil {
+					return stats, err
+				}
+			}
+		}
+	}
+
+	if err := flushUsers(); err != nil {
+		return stats, err
+	}
+	if err := flushFollows(); err != nil {
+		return stats, err
+	}
+	if err := flushBlocks(); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
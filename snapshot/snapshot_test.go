@@ -0,0 +1,253 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"database-example/model"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// memStore je Store u memoriji; pamti i veličine batch-eva i redosled poziva.
+type memStore struct {
+	users   []string
+	follows []model.FollowEdge
+	blocks  []model.BlockEdge
+
+	calls []string
+	// blocked: parovi "a|b" za koje ImportFollows preskače vezu (u oba smera)
+	blocked map[string]bool
+}
+
+func (s *memStore) ExportUsers(ctx context.Context, fn func(id string) error) error {
+	for _, id := range s.users {
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memStore) ExportFollows(ctx context.Context, fn func(model.FollowEdge) error) error {
+	for _, e := range s.follows {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memStore) ExportBlocks(ctx context.Context, fn func(model.BlockEdge) error) error {
+	for _, e := range s.blocks {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memStore) ImportUsers(ctx context.Context, ids []string) error {
+	s.calls = append(s.calls, "users:"+strconv.Itoa(len(ids)))
+	s.users = append(s.users, ids...)
+	return nil
+}
+
+func (s *memStore) ImportFollows(ctx context.Context, edges []model.FollowEdge) (int, error) {
+	s.calls = append(s.calls, "follows:"+strconv.Itoa(len(edges)))
+	skipped := 0
+	for _, e := range edges {
+		if s.blocked[e.FollowerID+"|"+e.FolloweeID] || s.blocked[e.FolloweeID+"|"+e.FollowerID] {
+			skipped++
+			continue
+		}
+		s.follows = append(s.follows, e)
+	}
+	return skipped, nil
+}
+
+func (s *memStore) ImportBlocks(ctx context.Context, edges []model.BlockEdge) error {
+	s.calls = append(s.calls, "blocks:"+strconv.Itoa(len(edges)))
+	s.blocks = append(s.blocks, edges...)
+	return nil
+}
+
+func ts(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func TestRoundTrip(t *testing.T) {
+	src := &memStore{
+		users: []string{"alice", "bob", "carol, \"the\" third"},
+		follows: []model.FollowEdge{
+			{FollowerID: "alice", FolloweeID: "bob", Since: ts("2024-03-01T10:00:00.123456789Z")},
+			{FollowerID: "bob", FolloweeID: "carol, \"the\" third"},
+		},
+		blocks: []model.BlockEdge{
+			{BlockerID: "carol, \"the\" third", BlockedID: "alice", Since: ts("2024-04-02T00:00:00Z")},
+		},
+	}
+	for _, format := range []Format{FormatJSONL, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			exported, err := Export(context.Background(), src, &buf, format, discardLogger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := Stats{Users: 3, Follows: 2, Blocks: 1}
+			if exported != want {
+				t.Errorf("export stats = %+v, want %+v", exported, want)
+			}
+
+			dst := &memStore{}
+			imported, err := Import(context.Background(), dst, &buf, format, ImportOptions{}, discardLogger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if imported != want {
+				t.Errorf("import stats = %+v, want %+v", imported, want)
+			}
+			if !reflect.DeepEqual(dst.users, src.users) ||
+				!reflect.DeepEqual(dst.follows, src.follows) ||
+				!reflect.DeepEqual(dst.blocks, src.blocks) {
+				t.Errorf("round trip mismatch:\n got %+v %+v %+v\nwant %+v %+v %+v",
+					dst.users, dst.follows, dst.blocks, src.users, src.follows, src.blocks)
+			}
+		})
+	}
+}
+
+func TestImportMalformed(t *testing.T) {
+	const header = "type,id,follower_id,followee_id,since\n"
+	tests := []struct {
+		name    string
+		format  Format
+		input   string
+		wantErr string
+	}{
+		{"jsonl bad json", FormatJSONL, `{"type":"user","id":"a"}` + "\n{", "line 2"},
+		{"jsonl unknown type", FormatJSONL, `{"type":"likes","id":"a"}`, `unknown record type "likes"`},
+		{"jsonl user without id", FormatJSONL, `{"type":"user","id":"  "}`, "user record without id"},
+		{"jsonl follow without followee", FormatJSONL, `{"type":"follows","followerId":"a"}`, "without follower or followee"},
+		{"jsonl self follow", FormatJSONL, `{"type":"follows","followerId":"a","followeeId":"a"}`, "follower equals followee"},
+		{"jsonl self block", FormatJSONL, `{"type":"blocks","blockerId":"a","blockedId":"a"}`, "blocker equals blocked"},
+		{"jsonl bad since", FormatJSONL, `{"type":"follows","followerId":"a","followeeId":"b","since":"yesterday"}`, "line 1"},
+		{"csv wrong header", FormatCSV, "type,id\nuser,a\n", "csv header"},
+		{"csv missing header", FormatCSV, "", "read csv header"},
+		{"csv wrong field count", FormatCSV, header + "user,a,,\n", "wrong number of fields"},
+		{"csv bad since", FormatCSV, header + "follows,,a,b,2024-13-01\n", "line 2: invalid since"},
+		{"csv block without blocked", FormatCSV, header + "user,a,,,\nblocks,,a,,\n", "line 3: blocks record without blocker or blocked id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Import(context.Background(), &memStore{}, strings.NewReader(tt.input), tt.format, ImportOptions{}, discardLogger)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportBatching(t *testing.T) {
+	input := strings.Join([]string{
+		`{"type":"user","id":"a"}`,
+		`{"type":"user","id":"b"}`,
+		`{"type":"user","id":"c"}`,
+		``,
+		`{"type":"user","id":"d"}`,
+		`{"type":"user","id":"e"}`,
+		`{"type":"follows","followerId":"a","followeeId":"b"}`,
+		`{"type":"follows","followerId":"a","followeeId":"c"}`,
+		`{"type":"follows","followerId":"a","followeeId":"d"}`,
+		`{"type":"blocks","blockerId":"e","blockedId":"a"}`,
+		`{"type":"blocks","blockerId":"e","blockedId":"b"}`,
+	}, "\n")
+
+	store := &memStore{}
+	stats, err := Import(context.Background(), store, strings.NewReader(input), FormatJSONL, ImportOptions{BatchSize: 2}, discardLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Stats{Users: 5, Follows: 3, Blocks: 2}); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+	// ostatak FOLLOWS batch-a se upisuje pre prvog BLOCKS reda
+	want := []string{"users:2", "users:2", "follows:2", "follows:1", "blocks:2", "users:1"}
+	if !reflect.DeepEqual(store.calls, want) {
+		t.Errorf("calls = %v, want %v", store.calls, want)
+	}
+}
+
+func TestImportReportsSkippedFollows(t *testing.T) {
+	input := strings.Join([]string{
+		`{"type":"follows","followerId":"a","followeeId":"b"}`,
+		`{"type":"follows","followerId":"b","followeeId":"a"}`,
+		`{"type":"follows","followerId":"a","followeeId":"c"}`,
+	}, "\n")
+	store := &memStore{blocked: map[string]bool{"a|b": true}}
+
+	stats, err := Import(context.Background(), store, strings.NewReader(input), FormatJSONL, ImportOptions{}, discardLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Follows != 1 || stats.SkippedFollows != 2 {
+		t.Errorf("stats = %+v, want 1 follow and 2 skipped", stats)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	input := `{"type":"user","id":"a"}` + "\n" + `{"type":"follows","followerId":"a","followeeId":"b"}`
+
+	stats, err := Import(context.Background(), nil, strings.NewReader(input), FormatJSONL, ImportOptions{DryRun: true}, discardLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Stats{Users: 1, Follows: 1}); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+
+	store := &memStore{}
+	if _, err := Import(context.Background(), store, strings.NewReader(input), FormatJSONL, ImportOptions{DryRun: true}, discardLogger); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.calls) != 0 {
+		t.Errorf("dry run wrote to store: %v", store.calls)
+	}
+
+	if _, err := Import(context.Background(), nil, strings.NewReader(input), FormatJSONL, ImportOptions{}, discardLogger); err == nil {
+		t.Error("import without store and without dry run: want error")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name, path string
+		want       Format
+		wantErr    bool
+	}{
+		{"", "graph.csv", FormatCSV, false},
+		{"", "graph.CSV", FormatCSV, false},
+		{"", "graph.jsonl", FormatJSONL, false},
+		{"", "-", FormatJSONL, false},
+		{"ndjson", "graph.csv", FormatJSONL, false},
+		{"CSV", "", FormatCSV, false},
+		{"xml", "", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name, tt.path)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q, %q) = %q, %v; want %q, err %v", tt.name, tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Admin konzola: koristi /admin/api/* sa admin JWT-om iz sessionStorage.
+(function () {
+    "use strict";
+
+    const PAGE = 20;
+    const state = { userId: null, followersSkip: 0, followeesSkip: 0 };
+    const $ = (id) => document.getElementById(id);
+
+    $("token").value = sessionStorage.getItem("adminToken") || "";
+
+    $("token-form").addEventListener("submit", (e) => {
+        e.preventDefault();
+        sessionStorage.setItem("adminToken", $("token").value.trim());
+        show("Token saved.");
+    });
+
+    $("lookup-form").addEventListener("submit", (e) => {
+        e.preventDefault();
+        load($("user-id").value.trim());
+    });
+
+    $("action-form").addEventListener("submit", async (e) => {
+        e.preventDefault();
+        const action = e.submitter.dataset.action;
+        const target = $("target-id").value.trim();
+        if (!state.userId || !target) {
+            return;
+        }
+        if (action === "block" && !confirm(`Block ${target} on behalf of ${state.userId}?`)) {
+            return;
+        }
+        const kind = action.endsWith("block") ? "block" : "follow";
+        const method = action.startsWith("un") ? "DELETE" : "POST";
+        const path = `/users/${enc(state.userId)}/${kind}/${enc(target)}`;
+        if (await api(method, path) !== null) {
+            show(`${action} ${state.userId} → ${target}: done.`);
+            load(state.userId);
+        }
+    });
+
+    $("followers-more").addEventListener("click", () => loadList("followers"));
+    $("followees-more").addEventListener("click", () => loadList("followees"));
+
+    async function load(userId) {
+        if (!userId) {
+            return;
+        }
+        const counts = await api("GET", `/users/${enc(userId)}`);
+        if (counts === null) {
+            $("user").hidden = true;
+            return;
+        }
+        hide();
+        state.userId = userId;
+        state.followersSkip = 0;
+        state.followeesSkip = 0;
+        $("user-id").value = userId;
+        $("current-user").textContent = userId;
+        $("followers-count").textContent = counts.followers;
+        $("followees-count").textContent = counts.followees;
+        $("followers").replaceChildren();
+        $("followees").replaceChildren();
+        $("user").hidden = false;
+
+        await Promise.all([loadList("followers"), loadList("followees"), loadRecommendations()]);
+    }
+
+    async function loadList(kind) {
+        const skipKey = kind + "Skip";
+        const resp = await api("GET", `/users/${enc(state.userId)}/${kind}?skip=${state[skipKey]}&limit=${PAGE}`);
+        if (resp === null) {
+            return;
+        }
+        const ids = resp.userIds || [];
+        for (const id of ids) {
+            $(kind).appendChild(userItem(id));
+        }
+        state[skipKey] += ids.length;
+        $(kind + "-more").hidden = ids.length < PAGE;
+    }
+
+    async function loadRecommendations() {
+        const resp = await api("GET", `/users/${enc(state.userId)}/recommendations?limit=10`);
+        const list = $("recommendations");
+        list.replaceChildren();
+        if (resp === null) {
+            return;
+        }
+        for (const rec of resp.items || []) {
+            const li = userItem(rec.userId);
+            const why = document.createElement("span");
+            why.className = "why";
+            why.textContent = explain(rec);
+            li.appendChild(why);
+            list.appendChild(li);
+        }
+    }
+
+    // "followed by a, b and 3 others you follow"
+    function explain(rec) {
+        const via = rec.via || [];
+        const mutual = Number(rec.mutual);
+        if (via.length === 0) {
+            return `${mutual} mutual`;
+        }
+        const rest = mutual - via.length;
+        return `followed by ${via.join(", ")}` + (rest > 0 ? ` and ${rest} other${rest > 1 ? "s" : ""}` : "") + " they follow";
+    }
+
+    function userItem(id) {
+        const li = document.createElement("li");
+        const a = document.createElement("a");
+        a.textContent = id;
+        a.addEventListener("click", () => load(id));
+        li.appendChild(a);
+        return li;
+    }
+
+    // api vraća parsiran JSON, {} za 204, ili null uz prikaz greške.
+    async function api(method, path) {
+        const token = sessionStorage.getItem("adminToken") || "";
+        try {
+            const resp = await fetch("/admin/api" + path, {
+                method,
+                headers: { Authorization: "Bearer " + token },
+            });
+            if (resp.status === 204) {
+                return {};
+            }
+            const body = await resp.json().catch(() => ({}));
+            if (!resp.ok) {
+                const err = body.error || {};
+                show(`${err.code || resp.status}: ${err.message || resp.statusText}`, true);
+                return null;
+            }
+            return body;
+        } catch (e) {
+            show(String(e), true);
+            return null;
+        }
+    }
+
+    function show(text, isError) {
+        const el = $("message");
+        el.textContent = text;
+        el.classList.toggle("error", !!isError);
+        el.hidden = false;
+    }
+
+    function hide() {
+        $("message").hidden = true;
+    }
+
+    function enc(s) {
+        return encodeURIComponent(s);
+    }
+})();
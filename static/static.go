@@ -0,0 +1,7 @@
+// Package static sadrži admin konzolu, ugrađenu u binarku.
+package static
+
+import "embed"
+
+//go:embed index.html admin.js admin.css
+var Files embed.FS
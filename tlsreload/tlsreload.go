@@ -0,0 +1,176 @@
+// Package tlsreload drži serverski sertifikat (i CA za klijentske sertifikate)
+// i ponovo ih učitava kad se fajlovi promene, bez restarta servisa. Promena se
+// otkriva proverom mtime/veličine, što radi i sa Kubernetes secret volume-ima
+// (gde se fajl menja zamenom symlink-a).
+package tlsreload
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"database-example/metrics"
+)
+
+// ClientAuth vrednosti iz konfiguracije.
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+)
+
+type Options struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile: CA bundle za proveru klijentskih sertifikata (mTLS)
+	ClientCAFile string
+	ClientAuth   string
+}
+
+// bundle je jedno učitano stanje fajlova; menja se atomski.
+type bundle struct {
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	stamp     string
+}
+
+type Reloader struct {
+	opts    Options
+	logger  *slog.Logger
+	current atomic.Pointer[bundle]
+}
+
+// New odmah učitava fajlove; greška ovde znači da server ne može da se pokrene.
+func New(opts Options, logger *slog.Logger) (*Reloader, error) {
+	r := &Reloader{opts: opts, logger: logger}
+	b, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.current.Store(b)
+	observeCert(b.cert)
+	return r, nil
+}
+
+// TLSConfig vraća konfiguraciju za grpc credentials.NewTLS. Svaki handshake
+// dobija trenutni sertifikat i CA pool, pa nove konekcije koriste nove fajlove,
+// a postojeće ostaju na starim dok se ne zatvore.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			b := r.current.Load()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*b.cert},
+				ClientCAs:    b.clientCAs,
+				ClientAuth:   r.clientAuth(),
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
+
+// Run proverava fajlove na svakih interval dok se ctx ne otkaže. Ako novi fajlovi
+// nisu ispravni (npr. cert je zamenjen, a ključ još nije), ostaje stari par.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reloadIfChanged()
+		}
+	}
+}
+
+func (r *Reloader) reloadIfChanged() {
+	stamp, err := r.stamp()
+	if err != nil {
+		r.logger.Warn("tls: cannot stat certificate files", "error", err)
+		return
+	}
+	if stamp == r.current.Load().stamp {
+		return
+	}
+	b, err := r.load()
+	if err != nil {
+		metrics.TLSReloads.WithLabelValues("error").Inc()
+		r.logger.Error("tls: certificate reload failed, keeping previous certificate", "error", err)
+		return
+	}
+	r.current.Store(b)
+	observeCert(b.cert)
+	metrics.TLSReloads.WithLabelValues("ok").Inc()
+	r.logger.Info("tls: certificate reloaded", "subject", b.cert.Leaf.Subject.String(), "not_after", b.cert.Leaf.NotAfter)
+}
+
+func (r *Reloader) load() (*bundle, error) {
+	// stamp pre čitanja: ako se fajl promeni u međuvremenu, sledeća provera ga ponovo učita
+	stamp, err := r.stamp()
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load server certificate: %w", err)
+	}
+	if cert.Leaf == nil {
+		// Leaf je popunjen osim uz GODEBUG=x509keypairleaf=0
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return nil, fmt.Errorf("parse server certificate: %w", err)
+		}
+	}
+	b := &bundle{cert: &cert, stamp: stamp}
+
+	if r.opts.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.opts.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA file: %w", err)
+		}
+		b.clientCAs = x509.NewCertPool()
+		if !b.clientCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("client CA file contains no PEM certificates")
+		}
+	}
+	return b, nil
+}
+
+// stamp je otisak (mtime i veličina) svih fajlova.
+func (r *Reloader) stamp() (string, error) {
+	var out string
+	for _, path := range []string{r.opts.CertFile, r.opts.KeyFile, r.opts.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		out += fmt.Sprintf("%s:%d:%d;", path, fi.ModTime().UnixNano(), fi.Size())
+	}
+	return out, nil
+}
+
+func (r *Reloader) clientAuth() tls.ClientAuthType {
+	switch r.opts.ClientAuth {
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert
+	case ClientAuthRequest:
+		return tls.VerifyClientCertIfGiven
+	default:
+		return tls.NoClientCert
+	}
+}
+
+func observeCert(cert *tls.Certificate) {
+	metrics.TLSCertNotAfter.Set(float64(cert.Leaf.NotAfter.Unix()))
+}
@@ -0,0 +1,317 @@
+package tlsreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA izdaje sertifikate za testove.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue vraća PEM sertifikata i ključa za cn (server ili klijent).
+func (ca *testCA) issue(t *testing.T, cn string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// write upisuje fajl i pomera mtime, da promena bude vidljiva i na
+// fajl sistemima sa grubom rezolucijom vremena.
+func write(t *testing.T, path string, data []byte, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type serverFiles struct {
+	ca       *testCA
+	opts     Options
+	modified time.Time
+}
+
+func newServerFiles(t *testing.T, cn string) *serverFiles {
+	t.Helper()
+	dir := t.TempDir()
+	f := &serverFiles{
+		ca: newTestCA(t, "server-ca"),
+		opts: Options{
+			CertFile: filepath.Join(dir, "tls.crt"),
+			KeyFile:  filepath.Join(dir, "tls.key"),
+		},
+		modified: time.Now().Add(-time.Hour),
+	}
+	f.replace(t, cn)
+	return f
+}
+
+// replace izdaje novi serverski par i upisuje ga sa novijim mtime-om.
+func (f *serverFiles) replace(t *testing.T, cn string) {
+	t.Helper()
+	certPEM, keyPEM := f.ca.issue(t, cn, x509.ExtKeyUsageServerAuth)
+	f.modified = f.modified.Add(time.Minute)
+	write(t, f.opts.CertFile, certPEM, f.modified)
+	write(t, f.opts.KeyFile, keyPEM, f.modified)
+}
+
+func newTestReloader(t *testing.T, opts Options) *Reloader {
+	t.Helper()
+	r, err := New(opts, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// servedCN vraća CN sertifikata koji bi dobio sledeći handshake.
+func servedCN(t *testing.T, r *Reloader) string {
+	t.Helper()
+	cfg, err := r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg.Certificates[0].Leaf.Subject.CommonName
+}
+
+func TestReloadOnChange(t *testing.T) {
+	files := newServerFiles(t, "v1")
+	r := newTestReloader(t, files.opts)
+
+	// bez promene fajlova ostaje isti bundle
+	before := r.current.Load()
+	r.reloadIfChanged()
+	if r.current.Load() != before {
+		t.Fatal("reloaded without a file change")
+	}
+
+	files.replace(t, "v2")
+	r.reloadIfChanged()
+	if cn := servedCN(t, r); cn != "v2" {
+		t.Fatalf("served CN = %q, want v2", cn)
+	}
+}
+
+func TestReloadKeepsOldCertificateOnError(t *testing.T) {
+	files := newServerFiles(t, "v1")
+	r := newTestReloader(t, files.opts)
+
+	// novi sertifikat, a ključ još stari (rotacija u toku)
+	certPEM, _ := files.ca.issue(t, "v2", x509.ExtKeyUsageServerAuth)
+	files.modified = files.modified.Add(time.Minute)
+	write(t, files.opts.CertFile, certPEM, files.modified)
+	r.reloadIfChanged()
+	if cn := servedCN(t, r); cn != "v1" {
+		t.Fatalf("served CN after mismatched pair = %q, want v1", cn)
+	}
+
+	write(t, files.opts.CertFile, []byte("not a certificate"), files.modified.Add(time.Minute))
+	r.reloadIfChanged()
+	if cn := servedCN(t, r); cn != "v1" {
+		t.Fatalf("served CN after corrupt file = %q, want v1", cn)
+	}
+
+	// obrisan fajl: stat ne uspeva, stari par ostaje
+	if err := os.Remove(files.opts.KeyFile); err != nil {
+		t.Fatal(err)
+	}
+	r.reloadIfChanged()
+	if cn := servedCN(t, r); cn != "v1" {
+		t.Fatalf("served CN after missing key = %q, want v1", cn)
+	}
+
+	// kad oba fajla stignu, novi par se učitava
+	files.replace(t, "v3")
+	r.reloadIfChanged()
+	if cn := servedCN(t, r); cn != "v3" {
+		t.Fatalf("served CN after fix = %q, want v3", cn)
+	}
+}
+
+func TestNewFailsOnInvalidFiles(t *testing.T) {
+	files := newServerFiles(t, "v1")
+	dir := t.TempDir()
+	emptyCA := filepath.Join(dir, "empty-ca.pem")
+	write(t, emptyCA, []byte("no certificates here"), time.Now())
+
+	tests := map[string]Options{
+		"missing cert": {CertFile: filepath.Join(dir, "missing.crt"), KeyFile: files.opts.KeyFile},
+		"key mismatch": {CertFile: files.opts.CertFile, KeyFile: files.opts.CertFile},
+		"missing CA":   {CertFile: files.opts.CertFile, KeyFile: files.opts.KeyFile, ClientCAFile: filepath.Join(dir, "missing-ca.pem")},
+		"empty CA":     {CertFile: files.opts.CertFile, KeyFile: files.opts.KeyFile, ClientCAFile: emptyCA},
+	}
+	for name, opts := range tests {
+		if _, err := New(opts, slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+			t.Errorf("%s: New succeeded, want error", name)
+		}
+	}
+}
+
+// handshake spaja klijenta i server preko loopback TCP-a (net.Pipe nema bafer,
+// pa bi se alert i klijentov Finished zaglavili) i vraća greške obe strane.
+func handshake(t *testing.T, server *tls.Config, client *tls.Config) (clientErr, serverErr error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		done <- tls.Server(conn, server).Handshake()
+	}()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	cl := tls.Client(conn, client)
+	clientErr = cl.Handshake()
+	if clientErr == nil {
+		// TLS 1.3: server proverava klijentski sertifikat posle klijentovog Finished;
+		// čitanje otkriva eventualno odbijanje
+		_ = conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+		if _, err := cl.Read(make([]byte, 1)); err != nil && !isTimeout(err) && err != io.EOF {
+			clientErr = err
+		}
+	}
+	return clientErr, <-done
+}
+
+func isTimeout(err error) bool {
+	ne, ok := err.(net.Error)
+	return ok && ne.Timeout()
+}
+
+func TestMutualTLS(t *testing.T) {
+	files := newServerFiles(t, "server")
+	clientCA := newTestCA(t, "client-ca")
+	files.opts.ClientCAFile = filepath.Join(t.TempDir(), "client-ca.pem")
+	write(t, files.opts.ClientCAFile, clientCA.pem, time.Now())
+
+	serverRoots := x509.NewCertPool()
+	serverRoots.AddCert(files.ca.cert)
+
+	clientCert := func(ca *testCA) []tls.Certificate {
+		certPEM, keyPEM := ca.issue(t, "feed-service", x509.ExtKeyUsageClientAuth)
+		pair, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []tls.Certificate{pair}
+	}
+	trusted := clientCert(clientCA)
+	untrusted := clientCert(newTestCA(t, "other-ca"))
+
+	tests := []struct {
+		name       string
+		clientAuth string
+		certs      []tls.Certificate
+		wantOK     bool
+	}{
+		{"require with trusted cert", ClientAuthRequire, trusted, true},
+		{"require without cert", ClientAuthRequire, nil, false},
+		{"require with untrusted cert", ClientAuthRequire, untrusted, false},
+		{"request without cert", ClientAuthRequest, nil, true},
+		{"request with untrusted cert", ClientAuthRequest, untrusted, false},
+		{"none ignores client cert", ClientAuthNone, untrusted, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := files.opts
+			opts.ClientAuth = tt.clientAuth
+			r := newTestReloader(t, opts)
+
+			client := &tls.Config{
+				ServerName: "localhost",
+				RootCAs:    serverRoots,
+				NextProtos: []string{"h2"},
+			}
+			if tt.certs != nil {
+				// šalje sertifikat i kad ga server ne traži od ovog CA, da server odluči
+				client.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					return &tt.certs[0], nil
+				}
+			}
+			clientErr, serverErr := handshake(t, r.TLSConfig(), client)
+			if ok := clientErr == nil && serverErr == nil; ok != tt.wantOK {
+				t.Fatalf("handshake ok = %v (client: %v, server: %v), want %v", ok, clientErr, serverErr, tt.wantOK)
+			}
+		})
+	}
+}
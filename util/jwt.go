@@ -2,39 +2,107 @@ package util
 
 import (
 	"context"
+	"crypto"
 	"errors"
 	"fmt"
-	"os"
+	"log/slog"
 	"strings"
 	"time"
 
+	"database-example/config"
+
 	"github.com/golang-jwt/jwt/v5"
 	"google.golang.org/grpc/metadata"
 )
 
-var jwtKey = func() []byte {
-	// U produkciji OBAVEZNO setovati kroz env var:
-	// u docker-compose: JWT_SECRET=neka_jaka_lozinka
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		// dev fallback – nemoj ostaviti u produkciji
-		secret = "tajna_lozinka"
+var (
+	jwtKey = []byte(config.DevJWTSecret)
+	jwtTTL = 24 * time.Hour
+	// verifier: dok se ne pozove ConfigureJWT, samo HS256 sa dev tajnom
+	verifier = &tokenVerifier{opts: []jwt.ParserOption{jwt.WithValidMethods([]string{"HS256"})}}
+)
+
+// tokenVerifier bira ključ po algoritmu (i kid-u) iz zaglavlja tokena.
+type tokenVerifier struct {
+	// static: ključevi iz PEM fajla; jwks: ključevi izdavaoca po kid-u
+	static []crypto.PublicKey
+	jwks   *jwks
+	opts   []jwt.ParserOption
+}
+
+// ConfigureJWT postavlja ključeve, dozvoljene algoritme i provere (iss, aud,
+// leeway) iz konfiguracije; poziva se jednom na startu.
+func ConfigureJWT(cfg config.JWTConfig, logger *slog.Logger) error {
+	jwtKey = []byte(cfg.Secret)
+	if cfg.TTL > 0 {
+		jwtTTL = cfg.TTL
+	}
+
+	v := &tokenVerifier{}
+	if cfg.PublicKeyFile != "" {
+		keys, err := loadPublicKeys(cfg.PublicKeyFile)
+		if err != nil {
+			return err
+		}
+		v.static = keys
+	}
+	if cfg.JWKS != "" {
+		set, err := newJWKS(cfg.JWKS, cfg.JWKSRefresh, logger)
+		if err != nil {
+			return fmt.Errorf("load jwks: %w", err)
+		}
+		v.jwks = set
+	}
+
+	v.opts = []jwt.ParserOption{jwt.WithValidMethods(cfg.Algorithms), jwt.WithLeeway(cfg.ClockSkewLeeway)}
+	if cfg.Issuer != "" {
+		v.opts = append(v.opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		v.opts = append(v.opts, jwt.WithAudience(cfg.Audience))
+	}
+	verifier = v
+	return nil
+}
+
+func (v *tokenVerifier) key(t *jwt.Token) (any, error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return jwtKey, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %T", t.Method)
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	if v.jwks != nil && (kid != "" || len(v.static) == 0) {
+		return v.jwks.key(kid)
+	}
+	if len(v.static) == 0 {
+		return nil, errors.New("no public key configured")
+	}
+	// PEM ključevi nemaju kid; parser proba svaki (i preskače pogrešan tip ključa)
+	set := jwt.VerificationKeySet{Keys: make([]jwt.VerificationKey, 0, len(v.static))}
+	for _, k := range v.static {
+		set.Keys = append(set.Keys, k)
 	}
-	return []byte(secret)
-}()
+	return set, nil
+}
 
 type Claims struct {
 	ID       string `json:"id"`
 	Username string `json:"username"`
 	// ostajemo kompatibilni sa Stakeholders servisom:
 	Role string `json:"http://schemas.microsoft.com/ws/2008/06/identity/claims/role"`
+	// Scope: scope-ovi servisnog tokena razdvojeni razmakom (RFC 8693)
+	Scope string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // (Opcionalno) Generisanje za lokalni test; u realnosti token izdaje gateway/auth.
 func GenerateToken(id, username, role string, ttl time.Duration) (string, error) {
 	if ttl <= 0 {
-		ttl = 24 * time.Hour
+		ttl = jwtTTL
 	}
 	now := time.Now()
 	claims := &Claims{
@@ -56,13 +124,8 @@ func ValidateToken(tokenStr string) (*Claims, error) {
 		return nil, errors.New("empty token")
 	}
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
-		// ograniči na HMAC
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %T", t.Method)
-		}
-		return jwtKey, nil
-	})
+	// WithValidMethods odbija algoritme van konfiguracije (npr. HS256 potpisan javnim ključem)
+	token, err := jwt.ParseWithClaims(tokenStr, claims, verifier.key, verifier.opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +146,15 @@ func ExtractBearer(ctx context.Context) (string, error) {
 	if len(values) == 0 {
 		return nilTokenErr()
 	}
-	parts := strings.SplitN(values[0], " ", 2)
+	return ParseBearer(values[0])
+}
+
+// ParseBearer izvlači token iz vrednosti Authorization header-a (gRPC metadata ili HTTP).
+func ParseBearer(header string) (string, error) {
+	if header == "" {
+		return nilTokenErr()
+	}
+	parts := strings.SplitN(header, " ", 2)
 	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
 		return "", errors.New("authorization header must be in format: Bearer <token>")
 	}
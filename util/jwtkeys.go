@@ -0,0 +1,287 @@
+package util
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// loadPublicKeys čita jedan ili više PEM blokova (PUBLIC KEY ili CERTIFICATE).
+func loadPublicKeys(path string) ([]crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read jwt public key file: %w", err)
+	}
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		var key crypto.PublicKey
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse jwt public key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwt public key file contains no PEM public keys")
+	}
+	return keys, nil
+}
+
+// jwks je keš ključeva iz JWKS dokumenta. Ključevi se osvežavaju kad istekne
+// refresh, a i ranije kad stigne token sa nepoznatim kid-om (rotacija kod
+// izdavaoca), ali najviše jednom u minRefetch. Fetch ide van j.mu: dok traje,
+// poznati ključevi se i dalje služe iz keša, a samo tokeni sa nepoznatim
+// kid-om čekaju njegov rezultat.
+type jwks struct {
+	source  string
+	refresh time.Duration
+	client  *http.Client
+	logger  *slog.Logger
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+	// inflight se zatvara kad fetch koji je u toku završi; nil ako fetch ne traje
+	inflight chan struct{}
+}
+
+const (
+	jwksMinRefetch   = 30 * time.Second
+	jwksFetchTimeout = 5 * time.Second
+	jwksMaxBytes     = 1 << 20
+)
+
+func newJWKS(source string, refresh time.Duration, logger *slog.Logger) (*jwks, error) {
+	j := &jwks{
+		source:  source,
+		refresh: refresh,
+		client:  &http.Client{Timeout: jwksFetchTimeout},
+		logger:  logger,
+	}
+	// bez ključeva nijedan token ne prolazi, pa je greška na startu fatalna
+	keys, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	j.keys, j.fetchedAt, j.lastAttempt = keys, time.Now(), time.Now()
+	return j, nil
+}
+
+// key vraća ključ za kid; prazan kid je dozvoljen samo ako JWKS ima jedan ključ.
+func (j *jwks) key(kid string) (crypto.PublicKey, error) {
+	j.mu.Lock()
+	key, ok := j.lookup(kid)
+	var done <-chan struct{}
+	if !ok || time.Since(j.fetchedAt) > j.refresh {
+		done = j.startFetch()
+	}
+	j.mu.Unlock()
+	if ok {
+		// zastareo ključ važi dok se osvežavanje ne završi u pozadini
+		return key, nil
+	}
+
+	if done != nil {
+		<-done
+		j.mu.Lock()
+		key, ok = j.lookup(kid)
+		j.mu.Unlock()
+	}
+	if !ok {
+		return nil, fmt.Errorf("no jwks key for kid %q", kid)
+	}
+	return key, nil
+}
+
+// startFetch (pod j.mu) pokreće fetch u pozadini ili vraća onaj koji je već u
+// toku; nil ako je poslednji pokušaj bio pre manje od minRefetch.
+func (j *jwks) startFetch() <-chan struct{} {
+	if j.inflight != nil {
+		return j.inflight
+	}
+	if time.Since(j.lastAttempt) < jwksMinRefetch {
+		return nil
+	}
+	j.lastAttempt = time.Now()
+	done := make(chan struct{})
+	j.inflight = done
+	go func() {
+		defer close(done)
+		keys, err := j.load()
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		j.inflight = nil
+		if err != nil {
+			// zadržavamo stare ključeve; izdavalac je možda samo privremeno nedostupan
+			j.logger.Warn("jwks refresh failed", "source", j.source, "error", err)
+			return
+		}
+		j.keys = keys
+		j.fetchedAt = time.Now()
+	}()
+	return done
+}
+
+func (j *jwks) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(j.keys) == 1 {
+		for _, k := range j.keys {
+			return k, true
+		}
+	}
+	k, ok := j.keys[kid]
+	return k, ok && kid != ""
+}
+
+// load čita i parsira JWKS; ne dira stanje keša, pa se poziva van j.mu.
+func (j *jwks) load() (map[string]crypto.PublicKey, error) {
+	data, err := j.read()
+	if err != nil {
+		return nil, err
+	}
+	return parseJWKS(data, j.logger)
+}
+
+func (j *jwks) read() ([]byte, error) {
+	if !strings.HasPrefix(j.source, "http://") && !strings.HasPrefix(j.source, "https://") {
+		return os.ReadFile(j.source)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, jwksMaxBytes))
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS prihvata RSA i EC (P-256) ključeve za potpis. Neispravan ili
+// nepodržan ključ se preskače uz log, da jedan loš unos kod izdavaoca ne obori
+// ostale; greška je samo ako ne ostane nijedan upotrebljiv ključ.
+func parseJWKS(data []byte, logger *slog.Logger) (map[string]crypto.PublicKey, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse jwks: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		switch {
+		case err != nil:
+			logger.Warn("skipping invalid jwks key", "kid", k.Kid, "kty", k.Kty, "error", err)
+		case key == nil:
+			logger.Debug("skipping unsupported jwks key", "kid", k.Kid, "kty", k.Kty, "crv", k.Crv)
+		default:
+			keys[k.Kid] = key
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks contains no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := b64Int(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := b64Int(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := b64Int(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := b64Int(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if _, err := key.ECDH(); err != nil {
+			return nil, errors.New("EC point is not on curve P-256")
+		}
+		return key, nil
+	default:
+		return nil, nil
+	}
+}
+
+func b64Int(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
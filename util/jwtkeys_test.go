@@ -0,0 +1,374 @@
+package util
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"database-example/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func rsaKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func ecKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, k *rsa.PublicKey) jwk {
+	return jwk{Kty: "RSA", Kid: kid, Use: "sig", N: b64(k.N.Bytes()), E: b64(big.NewInt(int64(k.E)).Bytes())}
+}
+
+func ecJWK(kid string, k *ecdsa.PublicKey) jwk {
+	return jwk{Kty: "EC", Kid: kid, Crv: "P-256", X: b64(k.X.Bytes()), Y: b64(k.Y.Bytes())}
+}
+
+func jwksDoc(t *testing.T, keys ...jwk) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string][]jwk{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func pemBlock(t *testing.T, typ string, der []byte) []byte {
+	t.Helper()
+	return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+}
+
+func pkixDER(t *testing.T, pub crypto.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestLoadPublicKeys(t *testing.T) {
+	ec, rs := ecKey(t), rsaKey(t)
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "issuer"}, NotAfter: time.Now().Add(time.Hour)}
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &ec.PublicKey, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data []byte
+	data = append(data, pemBlock(t, "PUBLIC KEY", pkixDER(t, &ec.PublicKey))...)
+	// privatni ključ u istom fajlu se preskače
+	data = append(data, pemBlock(t, "EC PRIVATE KEY", []byte("ignored"))...)
+	data = append(data, pemBlock(t, "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rs.PublicKey))...)
+	data = append(data, pemBlock(t, "CERTIFICATE", certDER)...)
+
+	keys, err := loadPublicKeys(writeFile(t, "keys.pem", data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("got %d keys, want 3", len(keys))
+	}
+	if !ec.PublicKey.Equal(keys[0]) || !rs.PublicKey.Equal(keys[1]) || !ec.PublicKey.Equal(keys[2]) {
+		t.Errorf("keys = %v, want ec, rsa, ec (cert)", keys)
+	}
+
+	for name, data := range map[string][]byte{
+		"no keys":     pemBlock(t, "EC PRIVATE KEY", []byte("x")),
+		"not pem":     []byte("hello"),
+		"corrupt key": pemBlock(t, "PUBLIC KEY", []byte("garbage")),
+	} {
+		if _, err := loadPublicKeys(writeFile(t, "k.pem", data)); err == nil {
+			t.Errorf("%s: loadPublicKeys succeeded, want error", name)
+		}
+	}
+	if _, err := loadPublicKeys(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("missing file: want error")
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	rs, ec := rsaKey(t), ecKey(t)
+	good := []jwk{rsaJWK("r1", &rs.PublicKey), ecJWK("e1", &ec.PublicKey)}
+
+	offCurve := ecJWK("bad-ec", &ec.PublicKey)
+	offCurve.Y = b64([]byte{1, 2, 3})
+	badN := rsaJWK("bad-rsa", &rs.PublicKey)
+	badN.N = "!!!"
+	p384 := ecJWK("p384", &ec.PublicKey)
+	p384.Crv = "P-384"
+	enc := rsaJWK("enc", &rs.PublicKey)
+	enc.Use = "enc"
+	oct := jwk{Kty: "oct", Kid: "hmac"}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		wantKids []string
+		wantErr  bool
+	}{
+		{"valid", jwksDoc(t, good...), []string{"r1", "e1"}, false},
+		{"bad and unsupported keys are skipped", jwksDoc(t, append([]jwk{offCurve, badN, p384, enc, oct}, good...)...), []string{"r1", "e1"}, false},
+		{"only unusable keys", jwksDoc(t, offCurve, badN, p384, enc, oct), nil, true},
+		{"empty", jwksDoc(t), nil, true},
+		{"not json", []byte("{"), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseJWKS(tt.data, discardLogger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(keys) != len(tt.wantKids) {
+				t.Fatalf("got %d keys, want %v", len(keys), tt.wantKids)
+			}
+			for _, kid := range tt.wantKids {
+				if keys[kid] == nil {
+					t.Errorf("missing key %q", kid)
+				}
+			}
+		})
+	}
+}
+
+// useJWTConfig poziva ConfigureJWT i vraća globalno stanje posle testa.
+func useJWTConfig(t *testing.T, cfg config.JWTConfig) {
+	t.Helper()
+	oldVerifier, oldKey, oldTTL := verifier, jwtKey, jwtTTL
+	t.Cleanup(func() { verifier, jwtKey, jwtTTL = oldVerifier, oldKey, oldTTL })
+	if err := ConfigureJWT(cfg, discardLogger); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.Claims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func userClaims(exp time.Time) *Claims {
+	return &Claims{ID: "42", RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(exp)}}
+}
+
+func TestValidateTokenKeySelection(t *testing.T) {
+	r1, r2, static := rsaKey(t), rsaKey(t), ecKey(t)
+	useJWTConfig(t, config.JWTConfig{
+		Algorithms:    []string{"RS256", "ES256"},
+		PublicKeyFile: writeFile(t, "static.pem", pemBlock(t, "PUBLIC KEY", pkixDER(t, &static.PublicKey))),
+		JWKS:          writeFile(t, "jwks.json", jwksDoc(t, rsaJWK("k1", &r1.PublicKey), rsaJWK("k2", &r2.PublicKey))),
+		JWKSRefresh:   time.Hour,
+	})
+	exp := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"jwks key by kid", sign(t, jwt.SigningMethodRS256, "k1", r1, userClaims(exp)), true},
+		{"second jwks key", sign(t, jwt.SigningMethodRS256, "k2", r2, userClaims(exp)), true},
+		{"kid points to another key", sign(t, jwt.SigningMethodRS256, "k2", r1, userClaims(exp)), false},
+		{"unknown kid", sign(t, jwt.SigningMethodRS256, "k3", r1, userClaims(exp)), false},
+		{"no kid uses static PEM keys", sign(t, jwt.SigningMethodES256, "", static, userClaims(exp)), true},
+		{"no kid, not a static key", sign(t, jwt.SigningMethodRS256, "", r1, userClaims(exp)), false},
+		{"algorithm not allowed", sign(t, jwt.SigningMethodHS256, "", []byte(config.DevJWTSecret), userClaims(exp)), false},
+		{"expired", sign(t, jwt.SigningMethodRS256, "k1", r1, userClaims(time.Now().Add(-time.Hour))), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ValidateToken(tt.token)
+			if (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok=%v", err, tt.ok)
+			}
+			if tt.ok && claims.ID != "42" {
+				t.Errorf("claims.ID = %q, want 42", claims.ID)
+			}
+		})
+	}
+}
+
+func TestValidateTokenRegisteredClaims(t *testing.T) {
+	const secret = "test-secret"
+	useJWTConfig(t, config.JWTConfig{
+		Secret:          secret,
+		Algorithms:      []string{"HS256"},
+		Issuer:          "https://auth.example",
+		Audience:        "follower-service",
+		ClockSkewLeeway: 30 * time.Second,
+	})
+	claims := func(iss, aud string, exp time.Time) *Claims {
+		c := userClaims(exp)
+		c.Issuer = iss
+		c.Audience = jwt.ClaimStrings{aud}
+		return c
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		claims *Claims
+		key    string
+		ok     bool
+	}{
+		{"valid", claims("https://auth.example", "follower-service", now.Add(time.Hour)), secret, true},
+		{"wrong issuer", claims("https://evil.example", "follower-service", now.Add(time.Hour)), secret, false},
+		{"wrong audience", claims("https://auth.example", "other-service", now.Add(time.Hour)), secret, false},
+		{"expired within leeway", claims("https://auth.example", "follower-service", now.Add(-10*time.Second)), secret, true},
+		{"expired beyond leeway", claims("https://auth.example", "follower-service", now.Add(-2*time.Minute)), secret, false},
+		{"wrong secret", claims("https://auth.example", "follower-service", now.Add(time.Hour)), config.DevJWTSecret, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateToken(sign(t, jwt.SigningMethodHS256, "", []byte(tt.key), tt.claims))
+			if (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok=%v", err, tt.ok)
+			}
+		})
+	}
+}
+
+// Dok osvežavanje traje (ili ne uspe), poznati ključevi se i dalje služe iz
+// keša; samo nepoznat kid čeka rezultat fetch-a.
+func TestJWKSRefreshServesCachedKeys(t *testing.T) {
+	k1, k2 := rsaKey(t), rsaKey(t)
+	first := jwksDoc(t, rsaJWK("k1", &k1.PublicKey))
+	second := jwksDoc(t, rsaJWK("k2", &k2.PublicKey))
+
+	var requests atomic.Int32
+	release := make(chan struct{})
+	failing := atomic.Bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Write(first)
+			return
+		}
+		<-release
+		if failing.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(second)
+	}))
+	defer srv.Close()
+	defer func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	}()
+
+	j, err := newJWKS(srv.URL, time.Minute, discardLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expire := func() {
+		j.mu.Lock()
+		j.fetchedAt = time.Now().Add(-time.Hour)
+		j.lastAttempt = time.Now().Add(-time.Hour)
+		j.mu.Unlock()
+	}
+	inflight := func() <-chan struct{} {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		return j.inflight
+	}
+
+	// zastareo keš: k1 se vraća odmah, iako fetch visi na serveru
+	expire()
+	if key, err := j.key("k1"); err != nil || !k1.PublicKey.Equal(key) {
+		t.Fatalf("cached k1: %v, %v", key, err)
+	}
+	done := inflight()
+	if done == nil {
+		t.Fatal("stale cache did not start a background refresh")
+	}
+
+	// nepoznat kid čeka fetch koji je već u toku
+	got := make(chan error, 1)
+	go func() {
+		_, err := j.key("k2")
+		got <- err
+	}()
+	select {
+	case err := <-got:
+		t.Fatalf("unknown kid returned before refresh finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	if err := <-got; err != nil {
+		t.Fatalf("k2 after refresh: %v", err)
+	}
+	<-done
+	if _, err := j.key("k1"); err == nil {
+		t.Error("k1 still served after it was rotated out")
+	}
+
+	// neuspelo osvežavanje zadržava poslednje ključeve
+	failing.Store(true)
+	expire()
+	if _, err := j.key("k2"); err != nil {
+		t.Fatalf("k2 during failing refresh: %v", err)
+	}
+	if done := inflight(); done != nil {
+		<-done
+	}
+	if _, err := j.key("k2"); err != nil {
+		t.Errorf("k2 after failed refresh: %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("server got %d requests, want 3", n)
+	}
+}
@@ -0,0 +1,37 @@
+package validation
+
+import (
+	followerpb "database-example/proto/follower"
+)
+
+// Validate proverava jedan zahtev. Poruke koje ovde nisu navedene (Ping,
+// health, reflection) prolaze bez provere.
+func (v *Validator) Validate(req any) error {
+	c := &violations{v: v}
+	switch r := req.(type) {
+	case *followerpb.FollowRequest:
+		c.pair("follower_id", r.GetFollowerId(), "followee_id", r.GetFolloweeId())
+	case *followerpb.UnfollowRequest:
+		c.pair("follower_id", r.GetFollowerId(), "followee_id", r.GetFolloweeId())
+	case *followerpb.BlockRequest:
+		c.pair("blocker_id", r.GetBlockerId(), "blocked_id", r.GetBlockedId())
+	case *followerpb.UnblockRequest:
+		c.pair("blocker_id", r.GetBlockerId(), "blocked_id", r.GetBlockedId())
+	case *followerpb.GetFolloweesRequest:
+		c.id("user_id", r.GetUserId())
+		c.skip(r.GetSkip())
+		c.limit(r.GetLimit())
+	case *followerpb.GetFollowersRequest:
+		c.id("user_id", r.GetUserId())
+		c.skip(r.GetSkip())
+		c.limit(r.GetLimit())
+	case *followerpb.GetRecommendationsRequest:
+		c.id("user_id", r.GetUserId())
+		c.limit(r.GetLimit())
+	case *followerpb.GetFollowCountsRequest:
+		c.id("user_id", r.GetUserId())
+	case *followerpb.GetFollowPolicyRequest:
+		c.id("user_id", r.GetUserId())
+	}
+	return c.err()
+}
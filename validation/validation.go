@@ -0,0 +1,122 @@
+// Package validation proverava FollowerService zahteve pre nego što stignu do
+// handler-a (interceptors.ValidationUnary). Sva pravila za jednu poruku su na
+// jednom mestu, a greška nosi povredu za svako neispravno polje.
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"database-example/apperr"
+
+	"github.com/google/uuid"
+)
+
+// IDFormat određuje kako izgleda ID korisnika (zavisi od servisa koji ih izdaje).
+type IDFormat string
+
+const (
+	// IDAny prihvata bilo koji neprazan string bez razmaka i kontrolnih znakova
+	IDAny     IDFormat = "any"
+	IDUUID    IDFormat = "uuid"
+	IDNumeric IDFormat = "numeric"
+)
+
+const (
+	// MaxIDLength važi za sve formate
+	MaxIDLength = 128
+	// DefaultMaxLimit je gornja granica za limit u listama
+	DefaultMaxLimit = 1000
+)
+
+var ErrInvalidRequest = apperr.Invalid("INVALID_REQUEST", "request validation failed")
+
+type Validator struct {
+	IDFormat IDFormat
+	MaxLimit int32
+}
+
+func New(format IDFormat, maxLimit int32) *Validator {
+	if format == "" {
+		format = IDAny
+	}
+	if maxLimit <= 0 {
+		maxLimit = DefaultMaxLimit
+	}
+	return &Validator{IDFormat: format, MaxLimit: maxLimit}
+}
+
+// violations skuplja povrede polja; err vraća nil ako ih nema.
+type violations struct {
+	v      *Validator
+	fields []apperr.FieldViolation
+}
+
+func (c *violations) add(field, format string, args ...any) {
+	c.fields = append(c.fields, apperr.Field(field, fmt.Sprintf(format, args...)))
+}
+
+func (c *violations) id(field, value string) bool {
+	if msg := c.v.checkID(value); msg != "" {
+		c.add(field, "%s", msg)
+		return false
+	}
+	return true
+}
+
+// pair: oba ID-ja moraju biti ispravna i različita (sebe se ne prati/blokira).
+func (c *violations) pair(firstField, first, secondField, second string) {
+	ok := c.id(firstField, first)
+	ok = c.id(secondField, second) && ok
+	if ok && first == second {
+		c.add(secondField, "must differ from %s", firstField)
+	}
+}
+
+func (c *violations) skip(value int32) {
+	if value < 0 {
+		c.add("skip", "must not be negative")
+	}
+}
+
+// limit: 0 znači podrazumevanu veličinu strane.
+func (c *violations) limit(value int32) {
+	if value < 0 || value > c.v.MaxLimit {
+		c.add("limit", "must be between 0 and %d", c.v.MaxLimit)
+	}
+}
+
+func (c *violations) err() error {
+	if len(c.fields) == 0 {
+		return nil
+	}
+	return apperr.Invalid(ErrInvalidRequest.Reason, ErrInvalidRequest.Message, c.fields...)
+}
+
+// checkID vraća opis problema ili "" ako je ID ispravan.
+func (v *Validator) checkID(id string) string {
+	switch {
+	case id == "":
+		return "must not be empty"
+	case len(id) > MaxIDLength:
+		return fmt.Sprintf("must be at most %d bytes", MaxIDLength)
+	case !utf8.ValidString(id):
+		return "must be valid UTF-8"
+	case strings.IndexFunc(id, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0:
+		return "must not contain whitespace or control characters"
+	}
+	switch v.IDFormat {
+	case IDUUID:
+		// uuid.Parse prihvata i {…} i urn: oblike, a mi samo kanonski
+		if _, err := uuid.Parse(id); err != nil || len(id) != 36 {
+			return "must be a UUID"
+		}
+	case IDNumeric:
+		if strings.IndexFunc(id, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+			return "must be numeric"
+		}
+	}
+	return ""
+}
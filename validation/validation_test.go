@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	followerpb "database-example/proto/follower"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fieldViolations vraća "polje: opis" iz google.rpc.BadRequest detalja greške.
+func fieldViolations(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %v, want InvalidArgument", st.Code())
+	}
+	var out []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				out = append(out, v.GetField()+": "+v.GetDescription())
+			}
+		}
+	}
+	if len(out) == 0 {
+		t.Fatalf("InvalidArgument without BadRequest field violations: %v", err)
+	}
+	return out
+}
+
+func TestValidate(t *testing.T) {
+	const uuidA = "0b6f1f5e-2c1e-4c43-9f4e-8d2f3c1a7b10"
+	const uuidB = "7d1c8a2e-5b3f-4e6a-9c0d-1f2e3a4b5c6d"
+
+	tests := []struct {
+		name   string
+		format IDFormat
+		req    any
+		want   []string
+	}{
+		{"any ok", IDAny, &followerpb.FollowRequest{FollowerId: "alice", FolloweeId: "bob"}, nil},
+		{"any empty ids", IDAny, &followerpb.FollowRequest{},
+			[]string{"follower_id: must not be empty", "followee_id: must not be empty"}},
+		{"any whitespace", IDAny, &followerpb.UnfollowRequest{FollowerId: "a b", FolloweeId: "c"},
+			[]string{"follower_id: must not contain whitespace or control characters"}},
+		{"any control char", IDAny, &followerpb.GetFollowCountsRequest{UserId: "a\x00"},
+			[]string{"user_id: must not contain whitespace or control characters"}},
+		{"any invalid utf8", IDAny, &followerpb.GetFollowPolicyRequest{UserId: "\xff"},
+			[]string{"user_id: must be valid UTF-8"}},
+
+		{"uuid ok", IDUUID, &followerpb.BlockRequest{BlockerId: uuidA, BlockedId: uuidB}, nil},
+		{"uuid rejects plain id", IDUUID, &followerpb.BlockRequest{BlockerId: "alice", BlockedId: uuidB},
+			[]string{"blocker_id: must be a UUID"}},
+		{"uuid rejects braces", IDUUID, &followerpb.GetFollowCountsRequest{UserId: "{" + uuidA + "}"},
+			[]string{"user_id: must be a UUID"}},
+
+		{"numeric ok", IDNumeric, &followerpb.FollowRequest{FollowerId: "12", FolloweeId: "34"}, nil},
+		{"numeric rejects letters", IDNumeric, &followerpb.FollowRequest{FollowerId: "12a", FolloweeId: "x"},
+			[]string{"follower_id: must be numeric", "followee_id: must be numeric"}},
+
+		{"max length ok", IDAny, &followerpb.GetFollowCountsRequest{UserId: strings.Repeat("a", MaxIDLength)}, nil},
+		{"max length exceeded", IDAny, &followerpb.GetFollowCountsRequest{UserId: strings.Repeat("a", MaxIDLength+1)},
+			[]string{"user_id: must be at most 128 bytes"}},
+
+		{"follow self", IDAny, &followerpb.FollowRequest{FollowerId: "alice", FolloweeId: "alice"},
+			[]string{"followee_id: must differ from follower_id"}},
+		{"block self", IDAny, &followerpb.UnblockRequest{BlockerId: "alice", BlockedId: "alice"},
+			[]string{"blocked_id: must differ from blocker_id"}},
+		{"invalid pair is not also reported as equal", IDAny, &followerpb.FollowRequest{FollowerId: "a b", FolloweeId: "a b"},
+			[]string{"follower_id: must not contain whitespace or control characters", "followee_id: must not contain whitespace or control characters"}},
+
+		{"negative skip", IDAny, &followerpb.GetFolloweesRequest{UserId: "alice", Skip: -1},
+			[]string{"skip: must not be negative"}},
+		{"limit above max", IDAny, &followerpb.GetFollowersRequest{UserId: "alice", Limit: 101},
+			[]string{"limit: must be between 0 and 100"}},
+		{"limit at max", IDAny, &followerpb.GetFollowersRequest{UserId: "alice", Limit: 100}, nil},
+		{"negative limit", IDAny, &followerpb.GetRecommendationsRequest{UserId: "alice", Limit: -5},
+			[]string{"limit: must be between 0 and 100"}},
+		{"all list violations together", IDAny, &followerpb.GetFolloweesRequest{Skip: -1, Limit: 1000},
+			[]string{"user_id: must not be empty", "skip: must not be negative", "limit: must be between 0 and 100"}},
+
+		{"unknown message passes", IDAny, &followerpb.PingRequest{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(tt.format, 100).Validate(tt.req)
+			if got := fieldViolations(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	v := New("", 0)
+	if v.IDFormat != IDAny || v.MaxLimit != DefaultMaxLimit {
+		t.Fatalf("New(\"\", 0) = %+v, want IDAny and DefaultMaxLimit", v)
+	}
+}
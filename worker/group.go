@@ -0,0 +1,49 @@
+// Package worker prati pozadinske gorutine (relay-evi, keševi, job-ovi) da bi
+// ih shutdown mogao ugasiti i sačekati pre zatvaranja Neo4j drajvera.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	logger *slog.Logger
+}
+
+func NewGroup(logger *slog.Logger) *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{ctx: ctx, cancel: cancel, logger: logger}
+}
+
+// Go pokreće fn u pozadini; fn mora da se vrati kada mu se ctx otkaže.
+func (g *Group) Go(name string, fn func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		fn(g.ctx)
+		g.logger.Info("worker stopped", "worker", name)
+	}()
+}
+
+// Stop otkazuje sve workere i čeka ih dok ctx ne istekne.
+func (g *Group) Stop(ctx context.Context) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}